@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	log_reporter "github.com/IANTHEREAL/logutil/reporter"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+	"github.com/IANTHEREAL/logutil/storage/leveldb"
+	"github.com/spf13/cobra"
+)
+
+func NewAmbiguityCmd() *cobra.Command {
+	cmdAmbiguity := &cobra.Command{
+		Use:          "ambiguity",
+		Short:        "Report the log patterns that share the same or overlapping signatures, and the ambiguous coverage they cause",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !Exists(LogCoverage) {
+				return fmt.Errorf("log coverage does't not exist")
+			}
+
+			fd := os.Stdout
+			if OutReport != "" {
+				var err error
+				fd, err = os.Create(OutReport)
+				if err != nil {
+					return err
+				}
+				defer fd.Close()
+			}
+
+			writer := bufio.NewWriter(fd)
+			AmbiguityReport(LogCoverage, writer)
+			return writer.Flush()
+		},
+	}
+
+	cmdAmbiguity.Flags().StringVar(&LogCoverage, "log-coverage", "", "the log coverage directory, or the extracted log pattern directory if there is no coverage yet")
+	cmdAmbiguity.Flags().StringVar(&OutReport, "output", "", "output report of ambiguity analysis results (default stdout)")
+	cmdAmbiguity.Flags().StringVar(&Template, "template", "", "output report template")
+	cmdAmbiguity.MarkFlagRequired("log-coverage")
+	return cmdAmbiguity
+}
+
+func AmbiguityReport(storePath string, output io.Writer) {
+	db, err := leveldb.Open(storePath, sharedStoreOptions)
+	if err != nil {
+		log.Fatalf("open leveldb failed %v", err)
+	}
+
+	store := keyvalue.NewLogPatternStore(db)
+
+	reporter, err := log_reporter.NewReporter(store, output)
+	if err != nil {
+		log.Fatalf("create coverage failed %v", err)
+	}
+
+	err = reporter.Render(Template, ambiguityTemplate)
+	if err != nil {
+		log.Fatalf("output ambiguity failed %v", err)
+	}
+}
+
+var ambiguityTemplate = `
+{{- $groups := .Ambiguities -}}
+ambiguous log pattern groups {{len $groups}}, ambiguous credits {{.AmbiguousCredits}} of total credits {{.Credits}}
+{{- println }}
+{{- range $group := $groups}}
+{{$group.Kind}} group of {{len $group.Logs}} log patterns, credits {{$group.CovCount}}, ambiguous credits {{$group.AmbiguousCount}}
+{{- range $log := $group.Logs}}
+log level {{$log.Pattern.Level}} at {{$log.Pattern.Pos.FilePath}}:{{$log.Pattern.Pos.LineNumber}} signatures {{- $log.Pattern.Signature}}
+{{- if $log.Coverage}} credits {{$log.Coverage.CovCount}}, ambiguous credits {{$log.Coverage.AmbiguousCount}}
+{{- range $sample := $log.Coverage.Samples}}
+sample {{$sample}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- println }}
+{{- end}}
+`
@@ -59,7 +59,7 @@ func NewAnalyzeCmd() *cobra.Command {
 }
 
 func Report(storePath string, output io.Writer) {
-	db, err := leveldb.Open(storePath, nil)
+	db, err := leveldb.Open(storePath, sharedStoreOptions)
 	if err != nil {
 		log.Fatalf("open leveldb failed %v", err)
 	}
@@ -96,7 +96,55 @@ coverage detail:
 {{- range $addr, $count := $cov.Coverage.CovCountByLog}}
 file {{$addr}} cover count {{$count}}
 {{- end}}
+{{- if $cov.FirstSeen}}
+first seen {{$cov.FirstSeen}}, last seen {{$cov.LastSeen}}
+{{- end}}
+{{- range $sample := $cov.Coverage.Samples}}
+sample {{$sample}}
+{{- end}}
 {{- println }}
 {{- else}} {{- end}}
 {{- end}}
+{{- if .ErrorCodeTotal}}
+total error code {{.ErrorCodeTotal}}, triggered error code {{.ErrorCodeCov}}
+{{- range $code := .UntriggeredErrorCodes}}
+never triggered error code {{$code.Code}} [class={{$code.Class}}:scope={{$code.Scope}}:level={{$code.Level}}] {{$code.Name}} {{printf "%q" $code.Message}} at {{$code.Pos.FilePath}}:{{$code.Pos.LineNumber}}
+{{- end}}
+{{- println }}
+{{- end}}
+{{- if .LoopLogs}}
+logs inside loops {{len .LoopLogs}}
+{{- range $d := .LoopLogs}}
+log level {{$d.Pattern.Level}} at {{$d.Pattern.Pos.FilePath}}:{{$d.Pattern.Pos.LineNumber}} loop depth {{$d.Pattern.Loop.Depth}} guarded {{$d.Pattern.Loop.Guarded}}
+{{- if $d.Pattern.Loop.Via}} via {{$d.Pattern.Loop.Via}}{{end}} cover count {{if $d.Coverage}}{{$d.Coverage.CovCount}}{{else}}0{{end}}
+{{- end}}
+{{- println }}
+{{- end}}
+{{- if .SilentErrorPaths}}
+silent error paths {{len .SilentErrorPaths}}
+{{- range $path := .SilentErrorPaths}}
+{{$path.Pos.FilePath}}:{{$path.Pos.LineNumber}} {{$path.Cond}} in {{$path.Boundary}} function {{$path.Func.Name}} returns without logging
+{{- end}}
+{{- println }}
+{{- end}}
+{{- if .UnknowLogs}}
+unknow logs {{len .UnknowLogs}}
+{{- range $unknow := .UnknowLogs}}
+log level {{$unknow.Level}} {{if $unknow.Pos.FilePath}}at {{$unknow.Pos.FilePath}}{{else}}message {{$unknow.Msg}}{{end}} count {{$unknow.CovCount}}
+{{- range $addr, $count := $unknow.CovCountByLog}}
+file {{$addr}} count {{$count}}
+{{- end}}
+{{- range $sample := $unknow.Samples}}
+sample {{$sample}}
+{{- end}}
+{{- end}}
+{{- println }}
+{{- end}}
+{{- if .ErrorPaths}}
+functions on failure paths {{len .ErrorPaths}}
+{{- range $path := .ErrorPaths}}
+function {{$path.Func.Name}} at {{$path.Func.Pos.FilePath}}:{{$path.Func.Pos.LineNumber}} appears in error stack count {{$path.Coverage.CovCount}}
+{{- end}}
+{{- println }}
+{{- end}}
 `
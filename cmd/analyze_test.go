@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+
+	recorder "github.com/IANTHEREAL/logutil/scanner/log_record"
+	log_scan "github.com/IANTHEREAL/logutil/scanner/log_scan"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+	"github.com/IANTHEREAL/logutil/storage/leveldb"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testAnalyzeSuite{})
+
+type testAnalyzeSuite struct {
+}
+
+func (t *testAnalyzeSuite) TestReportUnknowLogs(c *C) {
+	storePath := filepath.Join(c.MkDir(), "store")
+	db, err := leveldb.Open(storePath, nil)
+	c.Assert(err, IsNil)
+	store := keyvalue.NewLogPatternStore(db)
+
+	r := recorder.NewUnknowLogRecorder()
+	r.Record(&log_scan.Log{LogPath: "dm-worker.log", Level: "warn", Position: "main.go:12", Msg: `"retry"`})
+	r.Record(&log_scan.Log{LogPath: "dm-worker.log", Level: "error", Position: "main.go:12", Msg: `"exit"`})
+	r.Record(&log_scan.Log{LogPath: "dm-worker.log", Level: "error", Position: "main.go:12", Msg: `"exit"`})
+	r.Record(&log_scan.Log{LogPath: "dm-worker.log", Level: "info", Msg: `"started"`})
+	batch := store.Batch()
+	c.Assert(r.Flush(batch), IsNil)
+	c.Assert(batch.Commit(context.Background()), IsNil)
+	c.Assert(db.Close(context.Background()), IsNil)
+
+	var output bytes.Buffer
+	Report(storePath, &output)
+	c.Assert(output.String(), Matches, `(?s).*unknow logs 3
+log level error at main.go:12 count 2
+file dm-worker.log count 2
+sample "exit"
+log level info message "started" count 1
+file dm-worker.log count 1
+sample "started"
+log level warn at main.go:12 count 1
+file dm-worker.log count 1
+sample "retry".*`)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/IANTHEREAL/logutil/extractor/go/analyzer"
+	"github.com/IANTHEREAL/logutil/extractor/go/compiler"
+	logextractor "github.com/IANTHEREAL/logutil/extractor/go/log"
+	"github.com/IANTHEREAL/logutil/pkg/util"
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	"github.com/spf13/cobra"
+)
+
+func NewDupLogCmd() *cobra.Command {
+	cmdDupLog := &cobra.Command{
+		Use:          "dup-log",
+		Short:        "Find the errors that are logged and returned, then logged again by the callers",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// check all levels of logs if there is no filter config
+			rule := &logpattern_go_proto.LogPatternRule{}
+			if FlterConfig != "" {
+				if err := util.StrictDecodeFile(FlterConfig, rule); err != nil {
+					return err
+				}
+			}
+
+			if !Exists(Codebase) {
+				return fmt.Errorf("code %s doesn't exists", Codebase)
+			}
+
+			chains := FindDupLogChains(Codebase, rule)
+			for _, chain := range chains {
+				fmt.Println(chain)
+			}
+
+			if len(chains) > 0 {
+				return fmt.Errorf("found %d duplicate error logging chains", len(chains))
+			}
+			return nil
+		},
+	}
+
+	cmdDupLog.Flags().StringVar(&Codebase, "codebase", "./", "Source codebase directory for finding duplicate error logging")
+	cmdDupLog.Flags().StringVar(&FlterConfig, "filter", "", "the log filter rule config file, if no config file, all levels of logs are checked")
+	return cmdDupLog
+}
+
+// FindDupLogChains returns the call chains of codebase along which one error is logged more than once
+func FindDupLogChains(codebase string, rule *logpattern_go_proto.LogPatternRule) []*analyzer.DupLogChain {
+	filter := logextractor.NewFilter(rule)
+	pass := analyzer.NewDupLogPass(filter.Filter)
+
+	ai := analyzer.NewCompositeAnalyzer()
+	if err := ai.Register(pass); err != nil {
+		log.Fatalf("register analysis pass failed %v", err)
+	}
+
+	// the chains cross packages, so they are computed after all packages are visited
+	repo := buildRepo(codebase)
+	err := repo.ForEach(func(pkg *compiler.PackageCompilation) error {
+		pkg.ForEach(func(file *compiler.FileCompilation, helper *analyzer.AstHelper) {
+			err := file.RunAnalysis(ai, helper)
+			if err != nil {
+				log.Fatalf("analysis failed %v", err)
+			}
+		})
+
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("analyze failed %v", err)
+	}
+
+	return pass.Chains()
+}
@@ -7,11 +7,12 @@ import (
 	"log"
 	"os"
 	"path/filepath"
-	"sync"
 
 	"github.com/IANTHEREAL/logutil/extractor/go/analyzer"
 	"github.com/IANTHEREAL/logutil/extractor/go/compiler"
 	logextractor "github.com/IANTHEREAL/logutil/extractor/go/log"
+	regexextractor "github.com/IANTHEREAL/logutil/extractor/regex"
+	rustextractor "github.com/IANTHEREAL/logutil/extractor/rust"
 	"github.com/IANTHEREAL/logutil/pkg/util"
 	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
 	"github.com/IANTHEREAL/logutil/storage/keyvalue"
@@ -23,6 +24,8 @@ var (
 	Codebase    string
 	FlterConfig string
 	Output      string
+	Languages   []string
+	RegexConfig string
 
 	rule *logpattern_go_proto.LogPatternRule
 )
@@ -34,17 +37,10 @@ func NewExtractCmd() *cobra.Command {
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// handle filter config
-			if FlterConfig != "" {
-				rule = &logpattern_go_proto.LogPatternRule{}
-				// read from config file
-				if err := util.StrictDecodeFile(FlterConfig, rule); err != nil {
-					return err
-				}
-			} else {
-				// set default config, log_level = ["error"]
-				rule = &logpattern_go_proto.LogPatternRule{
-					LogLevel: []string{"error"},
-				}
+			var err error
+			rule, err = loadFilterRule(FlterConfig)
+			if err != nil {
+				return err
 			}
 
 			if !Exists(Codebase) {
@@ -66,13 +62,32 @@ func NewExtractCmd() *cobra.Command {
 			}
 			store := keyvalue.NewLogPatternStore(db)
 
-			ExtractLogPattern(store, Codebase, rule)
+			for _, lang := range Languages {
+				switch lang {
+				case "go":
+					ExtractLogPattern(store, Codebase, rule)
+				case "rust":
+					ExtractRustLogPattern(store, Codebase, rule)
+				default:
+					return fmt.Errorf("language %s is not supported", lang)
+				}
+			}
+
+			if RegexConfig != "" {
+				cfg, err := regexextractor.LoadConfig(RegexConfig)
+				if err != nil {
+					return err
+				}
+				ExtractRegexLogPattern(store, Codebase, rule, cfg)
+			}
 			return nil
 		},
 	}
 
 	cmdExtract.Flags().StringVar(&Codebase, "codebase", "./", "Source codebase directory for extracting log information")
 	cmdExtract.Flags().StringVar(&FlterConfig, "filter", "", "the log filter rule config file using json format, if no config file, default set logLevel = error")
+	cmdExtract.Flags().StringSliceVar(&Languages, "lang", []string{"go"}, "the languages of codebase to extract, go and rust are supported, e.g. --lang go,rust")
+	cmdExtract.Flags().StringVar(&RegexConfig, "regex-config", "", "the config file of regex extractor that finds logs in the sources of other languages by regexps")
 	cmdExtract.Flags().StringVar(&Output, "output", "", "the output file that stores the extracted log pattern and reference code information(default \"./${codebase-dirname}.logpattern\")")
 	return cmdExtract
 }
@@ -84,67 +99,161 @@ func ExtractLogPattern(store *keyvalue.Store, codebase string, rule *logpattern_
 	}
 
 	filter := logextractor.NewFilter(rule)
-	builder := &logextractor.Builder{}
+	repo := buildRepo(codebase)
 
-	path, err := filepath.Abs(codebase)
-	if err != nil {
-		log.Fatalf("absolute path %s error %v", codebase, err)
+	repoPath := &logpattern_go_proto.PackagePath{
+		Repo: repo.GetRepoPath(),
 	}
-	repo, err := builder.Build(build.Default, path)
-	if err != nil {
-		log.Fatalf("build failed %v", err)
-	}
-
-	ai := analyzer.NewAstAnalyzer(filter.Filter)
-	output := ai.SetupOutput()
 
-	done := sync.WaitGroup{}
-	done.Add(1)
-	go func() {
-		for {
-			lp, ok := <-output
-			if !ok {
-				break
+	// all passes share one AST walk per file, every pass writes its results under its own key prefix
+	var patterns []*logpattern_go_proto.LogPattern
+	loops := analyzer.NewLoopPass()
+	ai := analyzer.NewCompositeAnalyzer()
+	passes := []analyzer.Pass{
+		analyzer.NewLogPass(filter.Filter, func(pattern *logpattern_go_proto.LogPattern) {
+			pattern.Pos.PackagePath = repoPath
+			err := store.WriteLogPattern(context.Background(), pattern)
+			if err != nil {
+				log.Printf("wirte log %s failed %v", pattern, err)
 			}
-
-			pattern := lp.(*logpattern_go_proto.LogPattern)
-			pattern.Pos.PackagePath = &logpattern_go_proto.PackagePath{
-				Repo: repo.GetRepoPath(),
+			patterns = append(patterns, pattern)
+		}),
+		loops,
+		analyzer.NewTerrorPass(func(code *logpattern_go_proto.ErrorCode) {
+			code.Pos.PackagePath = repoPath
+			err := store.WriteErrorCode(context.Background(), code)
+			if err != nil {
+				log.Printf("wirte error code %s failed %v", code, err)
 			}
-
-			//log.Printf("result log %s", lp)
-			err := store.WriteLogPattern(context.Background(), pattern)
+		}),
+		// all levels of logs count for the silent error paths, so no log pattern rule is used
+		analyzer.NewSilentErrorPass(logextractor.NewFilter(nil).Filter, func(path *logpattern_go_proto.SilentErrorPath) {
+			path.Pos.PackagePath = repoPath
+			path.Func.Pos.PackagePath = repoPath
+			err := store.WriteSilentErrorPath(context.Background(), path)
 			if err != nil {
-				log.Printf("wirte log %s failed %v", lp, err)
+				log.Printf("wirte silent error path %s failed %v", path, err)
 			}
-
+		}),
+		analyzer.NewFuncPass(func(fn *logpattern_go_proto.FuncInfo) {
+			fn.Pos.PackagePath = repoPath
+			err := store.WriteFunction(context.Background(), fn)
+			if err != nil {
+				log.Printf("wirte function %s failed %v", fn, err)
+			}
+		}),
+	}
+	for _, pass := range passes {
+		if err := ai.Register(pass); err != nil {
+			log.Fatalf("register analysis pass failed %v", err)
 		}
-		done.Done()
-	}()
-
-	wg := sync.WaitGroup{}
+	}
 
 	err = repo.ForEach(func(pkg *compiler.PackageCompilation) error {
-		wg.Add(1)
-		//go func() {
 		pkg.ForEach(func(file *compiler.FileCompilation, helper *analyzer.AstHelper) {
 			err := file.RunAnalysis(ai, helper)
 			if err != nil {
 				log.Fatalf("analysis failed %v", err)
 			}
 		})
-		wg.Done()
-		//}()
 
 		return nil
 	})
-
-	wg.Wait()
-	ai.MarkDone()
 	if err != nil {
 		log.Fatalf("analyze failed %d", err)
 	}
-	done.Wait()
+
+	// the loops around the calls of functions are known after all files are visited
+	for _, pattern := range patterns {
+		if loops.Annotate(pattern) {
+			err := store.WriteLogPattern(context.Background(), pattern)
+			if err != nil {
+				log.Printf("wirte log %s failed %v", pattern, err)
+			}
+		}
+	}
+}
+
+// buildRepo compiles all go packages of the codebase
+func buildRepo(codebase string) *logextractor.Repo {
+	builder := &logextractor.Builder{}
+
+	path, err := filepath.Abs(codebase)
+	if err != nil {
+		log.Fatalf("absolute path %s error %v", codebase, err)
+	}
+	repo, err := builder.Build(build.Default, path)
+	if err != nil {
+		log.Fatalf("build failed %v", err)
+	}
+	return repo
+}
+
+// ExtractRustLogPattern extracts log patterns from the rust sources of codebase,
+// the repo of log patterns is the absolute path of codebase
+func ExtractRustLogPattern(store *keyvalue.Store, codebase string, rule *logpattern_go_proto.LogPatternRule) {
+	err := store.WriteLogPatternRule(context.Background(), rule)
+	if err != nil {
+		log.Fatalf("save log pattern rule into log patern store failed %v", err)
+	}
+
+	path, err := filepath.Abs(codebase)
+	if err != nil {
+		log.Fatalf("absolute path %s error %v", codebase, err)
+	}
+
+	extractor := rustextractor.NewExtractor(rule, path)
+	err = extractor.Extract(path, func(pattern *logpattern_go_proto.LogPattern) {
+		err := store.WriteLogPattern(context.Background(), pattern)
+		if err != nil {
+			log.Printf("wirte log %s failed %v", pattern, err)
+		}
+	})
+	if err != nil {
+		log.Fatalf("extract rust log pattern failed %v", err)
+	}
+}
+
+// ExtractRegexLogPattern extracts log patterns from the codebase by the regexps in config,
+// the repo of log patterns is the absolute path of codebase
+func ExtractRegexLogPattern(store *keyvalue.Store, codebase string, rule *logpattern_go_proto.LogPatternRule, cfg *regexextractor.Config) {
+	err := store.WriteLogPatternRule(context.Background(), rule)
+	if err != nil {
+		log.Fatalf("save log pattern rule into log patern store failed %v", err)
+	}
+
+	path, err := filepath.Abs(codebase)
+	if err != nil {
+		log.Fatalf("absolute path %s error %v", codebase, err)
+	}
+
+	extractor := regexextractor.NewExtractor(cfg, rule, path)
+	err = extractor.Extract(path, func(pattern *logpattern_go_proto.LogPattern) {
+		err := store.WriteLogPattern(context.Background(), pattern)
+		if err != nil {
+			log.Printf("wirte log %s failed %v", pattern, err)
+		}
+	})
+	if err != nil {
+		log.Fatalf("extract log pattern by regexps failed %v", err)
+	}
+}
+
+// loadFilterRule reads log pattern rule from the config file, if no config file, default set logLevel = error
+func loadFilterRule(config string) (*logpattern_go_proto.LogPatternRule, error) {
+	if config == "" {
+		// set default config, log_level = ["error"]
+		return &logpattern_go_proto.LogPatternRule{
+			LogLevel: []string{"error"},
+		}, nil
+	}
+
+	rule := &logpattern_go_proto.LogPatternRule{}
+	// read from config file
+	if err := util.StrictDecodeFile(config, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
 }
 
 func Exists(path string) bool {
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/IANTHEREAL/logutil/extractor/go/vet"
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+	"github.com/IANTHEREAL/logutil/storage/leveldb"
+	"github.com/spf13/cobra"
+)
+
+var (
+	VetInput string
+	VetRepo  string
+)
+
+func NewImportCmd() *cobra.Command {
+	cmdImport := &cobra.Command{
+		Use:          "import",
+		Short:        "Import log patterns from the JSON output of `go vet -a -vettool=logcov-vet -json`",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rule, err := loadFilterRule(FlterConfig)
+			if err != nil {
+				return err
+			}
+
+			input := os.Stdin
+			if VetInput != "-" {
+				input, err = os.Open(VetInput)
+				if err != nil {
+					return err
+				}
+				defer input.Close()
+			}
+
+			db, err := leveldb.Open(Output, nil)
+			if err != nil {
+				log.Fatalf("open leveldb failed %v", err)
+			}
+			store := keyvalue.NewLogPatternStore(db)
+
+			err = store.WriteLogPatternRule(context.Background(), rule)
+			if err != nil {
+				return fmt.Errorf("save log pattern rule into log patern store failed %v", err)
+			}
+
+			count, err := ImportVetLogPattern(store, input, VetRepo)
+			if err != nil {
+				return err
+			}
+			log.Printf("import %d log patterns", count)
+			return nil
+		},
+	}
+
+	cmdImport.Flags().StringVar(&VetInput, "input", "-", "the JSON output of logcov-vet, - means stdin")
+	cmdImport.Flags().StringVar(&VetRepo, "repo", "", "the repo path recorded in the position of log patterns, it overrides the repo set by logcov-vet")
+	cmdImport.Flags().StringVar(&FlterConfig, "filter", "", "the log filter rule config file that logcov-vet used, if no config file, default set logLevel = error")
+	cmdImport.Flags().StringVar(&Output, "output", "", "the output file that stores the imported log pattern information")
+	cmdImport.MarkFlagRequired("output")
+	return cmdImport
+}
+
+// vetDiagnostic is the diagnostic in the JSON output of go vet
+type vetDiagnostic struct {
+	Category string `json:"category"`
+	Posn     string `json:"posn"`
+	Message  string `json:"message"`
+}
+
+// ImportVetLogPattern writes the log patterns in the JSON output of logcov-vet into store, and returns the count of patterns.
+// The JSON output is a stream of trees, one for each package, go vet puts a "# package" line before every tree, e.g.
+//
+//	{"github.com/org/repo/pkg": {"logcov": [{"category": "logpattern", "posn": "...", "message": "{...}"}]}}
+//
+// the analysis errors of packages are logged and skipped
+func ImportVetLogPattern(store *keyvalue.Store, r io.Reader, repo string) (int, error) {
+	count := 0
+	decoder := json.NewDecoder(&vetOutputReader{scanner: bufio.NewScanner(r)})
+	for {
+		tree := make(map[string]map[string]json.RawMessage)
+		err := decoder.Decode(&tree)
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, fmt.Errorf("decode vet output failed %v", err)
+		}
+
+		for pkg, analyzers := range tree {
+			result, ok := analyzers[vet.Analyzer.Name]
+			if !ok {
+				continue
+			}
+
+			var diagnostics []vetDiagnostic
+			if err := json.Unmarshal(result, &diagnostics); err != nil {
+				// the analysis of package failed, e.g. {"error": "..."}
+				log.Printf("skip package %s: %s", pkg, result)
+				continue
+			}
+
+			for _, diag := range diagnostics {
+				if diag.Category != vet.LogPatternCategory {
+					continue
+				}
+
+				pattern := &logpattern_go_proto.LogPattern{}
+				if err := json.Unmarshal([]byte(diag.Message), pattern); err != nil {
+					return count, fmt.Errorf("decode log pattern at %s failed %v", diag.Posn, err)
+				}
+				if repo != "" || pattern.Pos.PackagePath == nil {
+					pattern.Pos.PackagePath = &logpattern_go_proto.PackagePath{
+						Repo: repo,
+					}
+				}
+
+				if err := store.WriteLogPattern(context.Background(), pattern); err != nil {
+					return count, err
+				}
+				count++
+			}
+		}
+	}
+}
+
+// vetOutputReader strips the "# package" lines from the output of go vet
+type vetOutputReader struct {
+	scanner *bufio.Scanner
+	buf     []byte
+}
+
+func (r *vetOutputReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if !r.scanner.Scan() {
+			if err := r.scanner.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+
+		line := r.scanner.Bytes()
+		if len(line) > 0 && line[0] == '#' {
+			continue
+		}
+		r.buf = append(append(r.buf, line...), '\n')
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
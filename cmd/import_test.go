@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+	"github.com/IANTHEREAL/logutil/storage/leveldb"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testVetImportSuite{})
+
+type testVetImportSuite struct {
+}
+
+const testVetOutput = `# example.com/demo/a
+{
+	"example.com/demo/a": {
+		"logcov": [
+			{
+				"category": "logpattern",
+				"posn": "/demo/a/a.go:11:26",
+				"message": "{\"pos\":{\"file_path\":\"/demo/a/a.go\",\"line_number\":11,\"column_offset\":151},\"func\":{\"name\":\"local\",\"pos\":{\"file_path\":\"/demo/a/a.go\",\"line_number\":11,\"column_offset\":126}},\"level\":\"fatal\",\"signature\":[\"\\\"direct fatal\\\"\"]}"
+			}
+		]
+	}
+}
+# example.com/demo/b
+{
+	"example.com/demo/b": {
+		"logcov": {
+			"error": "type check failed"
+		}
+	}
+}
+# example.com/demo/c
+{
+	"example.com/demo/c": {
+		"logcov": [
+			{
+				"category": "logpattern",
+				"posn": "/demo/c/c.go:6:14",
+				"message": "{\"pos\":{\"file_path\":\"/demo/c/c.go\",\"line_number\":6,\"column_offset\":68},\"func\":{\"name\":\"Do\",\"pos\":{\"file_path\":\"/demo/c/c.go\",\"line_number\":5,\"column_offset\":43}},\"level\":\"fatal\",\"signature\":[\"\\\"wrapped fatal\\\"\"]}"
+			}
+		]
+	}
+}
+`
+
+func (t *testVetImportSuite) TestImportVetLogPattern(c *C) {
+	tmpdir, err := ioutil.TempDir("./", "logpattern_test")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(tmpdir)
+
+	db, err := leveldb.Open(tmpdir, nil)
+	c.Assert(err, IsNil)
+	store := keyvalue.NewLogPatternStore(db)
+
+	count, err := ImportVetLogPattern(store, strings.NewReader(testVetOutput), "github.com/example/demo")
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, 2)
+
+	var patterns []*logpattern_go_proto.LogPattern
+	err = store.ScanLogPattern(context.Background(), func(_, value []byte) error {
+		lp := &logpattern_go_proto.LogPattern{}
+		patterns = append(patterns, lp)
+		return lp.Unmarshal(value)
+	})
+	c.Assert(err, IsNil)
+	c.Assert(patterns, HasLen, 2)
+	c.Assert(patterns[0].Pos.FilePath, Equals, "/demo/a/a.go")
+	c.Assert(patterns[0].Pos.PackagePath.Repo, Equals, "github.com/example/demo")
+	c.Assert(patterns[0].Func.Name, Equals, "local")
+	c.Assert(patterns[0].Level, Equals, "fatal")
+	c.Assert(patterns[1].Signature, DeepEquals, []string{`"wrapped fatal"`})
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/IANTHEREAL/logutil/extractor/go/analyzer"
+	"github.com/IANTHEREAL/logutil/extractor/go/compiler"
+	logextractor "github.com/IANTHEREAL/logutil/extractor/go/log"
+	"github.com/IANTHEREAL/logutil/pkg/util"
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	LintRules     []string
+	LintListRules bool
+)
+
+func NewLintCmd() *cobra.Command {
+	cmdLint := &cobra.Command{
+		Use:          "lint",
+		Short:        "Check the quality of log statements in codebase, e.g. error logs without error field",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if LintListRules {
+				for _, r := range analyzer.LintRules {
+					fmt.Printf("%-20s %s\n", r.Name, r.Doc)
+				}
+				return nil
+			}
+
+			// lint all levels of logs if there is no filter config
+			rule := &logpattern_go_proto.LogPatternRule{}
+			if FlterConfig != "" {
+				if err := util.StrictDecodeFile(FlterConfig, rule); err != nil {
+					return err
+				}
+			}
+
+			if !Exists(Codebase) {
+				return fmt.Errorf("code %s doesn't exists", Codebase)
+			}
+
+			diagnostics, err := LintLogPattern(Codebase, rule, LintRules)
+			if err != nil {
+				return err
+			}
+			for _, d := range diagnostics {
+				fmt.Println(d)
+			}
+
+			if len(diagnostics) > 0 {
+				return fmt.Errorf("found %d lint issues", len(diagnostics))
+			}
+			return nil
+		},
+	}
+
+	var names []string
+	for _, r := range analyzer.LintRules {
+		names = append(names, r.Name)
+	}
+	cmdLint.Flags().StringVar(&Codebase, "codebase", "./", "Source codebase directory for linting log statements")
+	cmdLint.Flags().StringVar(&FlterConfig, "filter", "", "the log filter rule config file, if no config file, all levels of logs are linted")
+	cmdLint.Flags().StringSliceVar(&LintRules, "rules", nil, fmt.Sprintf("the lint rules to run, all rules are run by default, available rules: %s", strings.Join(names, ",")))
+	cmdLint.Flags().BoolVar(&LintListRules, "list-rules", false, "list all lint rules")
+	return cmdLint
+}
+
+// LintLogPattern runs the lint rules on the log statements of codebase,
+// returns the diagnostics sorted by position
+func LintLogPattern(codebase string, rule *logpattern_go_proto.LogPatternRule, rules []string) ([]*analyzer.LintDiagnostic, error) {
+	var diagnostics []*analyzer.LintDiagnostic
+	filter := logextractor.NewFilter(rule)
+	pass, err := analyzer.NewLintPass(filter.Filter, rules, func(d *analyzer.LintDiagnostic) {
+		diagnostics = append(diagnostics, d)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ai := analyzer.NewCompositeAnalyzer()
+	if err := ai.Register(pass); err != nil {
+		return nil, err
+	}
+
+	repo := buildRepo(codebase)
+	err = repo.ForEach(func(pkg *compiler.PackageCompilation) error {
+		pkg.ForEach(func(file *compiler.FileCompilation, helper *analyzer.AstHelper) {
+			err := file.RunAnalysis(ai, helper)
+			if err != nil {
+				log.Fatalf("analysis failed %v", err)
+			}
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i].Pos, diagnostics[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return diagnostics, nil
+}
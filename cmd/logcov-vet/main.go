@@ -0,0 +1,14 @@
+// logcov-vet extracts log patterns through the go vet protocol, e.g.
+//
+//	go vet -a -vettool=$(which logcov-vet) -json ./... 2> patterns.json
+//	logcov import --input patterns.json --output ./repo.logpattern
+package main
+
+import (
+	"github.com/IANTHEREAL/logutil/extractor/go/vet"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(vet.Analyzer)
+}
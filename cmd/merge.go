@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	log_merger "github.com/IANTHEREAL/logutil/merger"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+	"github.com/IANTHEREAL/logutil/storage/leveldb"
+	"github.com/spf13/cobra"
+)
+
+func NewMergeCmd() *cobra.Command {
+	cmdMerge := &cobra.Command{
+		Use:          "merge shard1 shard2 ...",
+		Short:        "Merge the log coverage of log pattern sets that are extracted from the same code, e.g. the log pattern sets of parallel CI shards",
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, err := filepath.Abs(Output)
+			if err != nil {
+				return err
+			}
+			for _, input := range args {
+				if abs, err := filepath.Abs(input); err == nil && abs == output {
+					return fmt.Errorf("output %s is one of the inputs", Output)
+				}
+			}
+			if _, err := os.Stat(Output); err == nil {
+				return fmt.Errorf("output %s already exists", Output)
+			}
+
+			MergeLogCoverage(Output, args)
+			return nil
+		},
+	}
+
+	cmdMerge.Flags().StringVar(&Output, "output", "", "the output file that stores the log patterns and the merged log coverage, the log files of coverage are prefixed by their log pattern sets, e.g. shard1:/tmp/dm-worker.log")
+	cmdMerge.MarkFlagRequired("output")
+	return cmdMerge
+}
+
+func MergeLogCoverage(output string, inputs []string) {
+	merger := log_merger.NewMerger()
+	for _, input := range inputs {
+		db, err := leveldb.Open(input, &leveldb.Options{
+			CacheCapacity:   leveldb.DefaultOptions.CacheCapacity,
+			WriteBufferSize: leveldb.DefaultOptions.WriteBufferSize,
+			MustExist:       true,
+		})
+		if err != nil {
+			log.Fatalf("open leveldb failed %v", err)
+		}
+		// the first input is read by merger until the merged coverage is written
+		defer db.Close(context.Background())
+
+		if err := merger.Add(input, keyvalue.NewLogPatternStore(db)); err != nil {
+			log.Fatalf("merge %s failed %v", input, err)
+		}
+	}
+
+	if conflicts := merger.Conflicts(); len(conflicts) > 0 {
+		for _, conflict := range conflicts {
+			fmt.Println(conflict)
+		}
+		log.Fatalf("log pattern sets are not extracted from the same code, %d conflicts", len(conflicts))
+	}
+
+	db, err := leveldb.Open(output, nil)
+	if err != nil {
+		log.Fatalf("open leveldb failed %v", err)
+	}
+	if err := merger.Write(keyvalue.NewLogPatternStore(db)); err != nil {
+		log.Fatalf("write merged log coverage failed %v", err)
+	}
+	if err := db.Close(context.Background()); err != nil {
+		log.Fatalf("close leveldb failed %v", err)
+	}
+	log.Printf("merge %d log pattern sets into %s", len(inputs), output)
+}
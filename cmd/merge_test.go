@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+	"github.com/IANTHEREAL/logutil/storage/leveldb"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testMergeSuite{})
+
+type testMergeSuite struct {
+}
+
+func (t *testMergeSuite) TestMergeLogCoverage(c *C) {
+	ctx := context.Background()
+	dir := c.MkDir()
+	var inputs []string
+	for _, name := range []string{"shard1", "shard2"} {
+		input := filepath.Join(dir, name)
+		db, err := leveldb.Open(input, nil)
+		c.Assert(err, IsNil)
+		store := keyvalue.NewLogPatternStore(db)
+		pos := &logpattern_go_proto.Position{
+			PackagePath: &logpattern_go_proto.PackagePath{Repo: "github.com/pingcap/ticdc/dm"},
+			FilePath:    filepath.Join(dir, "checkout-"+name, "dm/worker/server.go"),
+			LineNumber:  10,
+		}
+		c.Assert(store.WriteLogPattern(ctx, &logpattern_go_proto.LogPattern{Pos: pos, Level: "error"}), IsNil)
+		c.Assert(store.WriteLogCoverage(ctx, &logpattern_go_proto.Coverage{Pos: pos, CovCount: 1, CovCountByLog: map[string]int64{"dm-worker.log": 1}}), IsNil)
+		c.Assert(db.Close(ctx), IsNil)
+		inputs = append(inputs, input)
+	}
+
+	// the output can't be one of the inputs
+	cmd := NewMergeCmd()
+	cmd.SetArgs(append(inputs, "--output", inputs[1]+"/"))
+	c.Assert(cmd.Execute(), ErrorMatches, ".* is one of the inputs")
+
+	// the shards extracted in different checkouts are merged, and the log pattern sets are closed after merging
+	output := filepath.Join(dir, "merged")
+	MergeLogCoverage(output, inputs)
+	for _, path := range append(inputs, output) {
+		db, err := leveldb.Open(path, nil)
+		c.Assert(err, IsNil)
+		c.Assert(db.Close(ctx), IsNil)
+	}
+
+	db, err := leveldb.Open(output, nil)
+	c.Assert(err, IsNil)
+	defer db.Close(ctx)
+	store := keyvalue.NewLogPatternStore(db)
+	var count int64
+	c.Assert(store.ScanLogCoverage(ctx, func(_, value []byte) error {
+		cov := &logpattern_go_proto.Coverage{}
+		if err := cov.Unmarshal(value); err != nil {
+			return err
+		}
+		count += cov.CovCount
+		return nil
+	}), IsNil)
+	c.Assert(count, Equals, int64(2))
+}
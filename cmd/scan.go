@@ -3,21 +3,48 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/IANTHEREAL/logutil/pkg/util"
 	log_scanner "github.com/IANTHEREAL/logutil/scanner"
+	recorder "github.com/IANTHEREAL/logutil/scanner/log_record"
+	log_scan "github.com/IANTHEREAL/logutil/scanner/log_scan"
 	"github.com/IANTHEREAL/logutil/storage/keyvalue"
 	"github.com/IANTHEREAL/logutil/storage/leveldb"
 	"github.com/spf13/cobra"
 )
 
 var (
-	LogPattern string
-	LogFile    string
+	LogPattern      string
+	LogFile         string
+	JSONKeys        map[string]string
+	LogPrefix       string
+	LogfmtKeys      map[string]string
+	ParserConfig    string
+	ArchiveIncludes []string
+	LogIncludes     []string
+	LogExcludes     []string
+	Follow          bool
+	FlushInterval   time.Duration
+	ResetCoverage   bool
+	SampleSize      int
 )
 
+// sharedStoreOptions opens the log pattern set that may be opened by `logcov scan --follow` meanwhile,
+// the opening waits for the flushing of coverage
+var sharedStoreOptions = &leveldb.Options{
+	CacheCapacity:   leveldb.DefaultOptions.CacheCapacity,
+	WriteBufferSize: leveldb.DefaultOptions.WriteBufferSize,
+	LockTimeout:     10 * time.Second,
+}
+
 func NewScanCmd() *cobra.Command {
 	cmdScan := &cobra.Command{
 		Use:   "scan",
@@ -38,24 +65,270 @@ func NewScanCmd() *cobra.Command {
 				return fmt.Errorf("log pattern set doesn't exist")
 			}
 
-			files := strings.Split(LogFile, ",")
+			if len(JSONKeys) > 0 {
+				keys, err := jsonLogKeys(JSONKeys)
+				if err != nil {
+					return err
+				}
+				log_scan.RegisterLogParser("json", log_scan.NewJSONLogParser(keys))
+			}
+			if len(LogfmtKeys) > 0 {
+				keys, err := logfmtLogKeys(LogfmtKeys)
+				if err != nil {
+					return err
+				}
+				log_scan.RegisterLogParser("logfmt_custom", log_scan.NewLogfmtLogParser(keys))
+			}
+			if ParserConfig != "" {
+				cfg, err := log_scan.LoadParserConfig(ParserConfig)
+				if err != nil {
+					return err
+				}
+				cfg.Register()
+			}
+			if LogPrefix != "" {
+				log_scan.RegisterLogParser("log_prefix", log_scan.NewStdLogParser(LogPrefix))
+			}
+
+			for _, glob := range ArchiveIncludes {
+				log_scan.RegisterArchiveInclude(glob)
+			}
+			recorder.SetSampleSize(SampleSize)
+
+			if Follow {
+				// the followed logs are files or stdin, the directories and globs are not expanded
+				files := followLogFiles(strings.Split(LogFile, ","))
+				if len(files) <= 0 {
+					return fmt.Errorf("log files don't exist")
+				}
+				FollowLog(LogPattern, files, FlushInterval, ResetCoverage)
+				return nil
+			}
+
+			files, err := logFiles(strings.Split(LogFile, ","), LogIncludes, LogExcludes)
+			if err != nil {
+				return err
+			}
 			if len(files) <= 0 {
 				return fmt.Errorf("log files don't exist")
 			}
 
-			ScanLog(LogPattern, files)
+			ScanLog(LogPattern, files, ResetCoverage)
 			return nil
 		},
 	}
 
 	cmdScan.Flags().StringVar(&LogPattern, "log-pattern", "", "the directory that stores the extracted log pattern information, `log scan` would also add log coverage into it")
-	cmdScan.Flags().StringVar(&LogFile, "logs", "", " the program runtime log files, files are separated by commas")
+	cmdScan.Flags().StringVar(&LogFile, "logs", "", " the program runtime log files, files are separated by commas, the parser of file can be forced by parser:path, e.g. zap:dm-worker.log, and the tar and zip archives are scanned without extracting. "+
+		"The directories are walked recursively, and the globs and @filelist that lists log files line by line are expanded")
+	cmdScan.Flags().StringSliceVar(&LogIncludes, "include", nil, "the globs of log files that are scanned in the directories and globs of --logs, e.g. --include '*.log,*.log.gz'")
+	cmdScan.Flags().StringSliceVar(&LogExcludes, "exclude", nil, "the globs of log files that are not scanned in the directories and globs of --logs, e.g. --exclude '*.toml'")
+	cmdScan.Flags().StringToStringVar(&JSONKeys, "json-keys", nil, "the key names of JSON logs that are not printed by zap, e.g. --json-keys level=severity,time=timestamp,caller=source,msg=message")
+	cmdScan.Flags().StringToStringVar(&LogfmtKeys, "logfmt-keys", nil, "the key aliases of logfmt logs separated by |, e.g. --logfmt-keys level=severity|lvl,msg=message")
+	cmdScan.Flags().StringVar(&LogPrefix, "std-log-prefix", "", "the prefix set by log.SetPrefix of the standard library log package, it's stripped from the messages printed with Lmsgprefix")
+	cmdScan.Flags().StringVar(&ParserConfig, "parser-config", "", "the config file of user-defined log parsers by regexps or grok patterns, and the log files parsed by them")
+	cmdScan.Flags().StringSliceVar(&ArchiveIncludes, "archive-include", nil, "the globs of member names that are scanned in the tar and zip log archives, e.g. --archive-include '*.log,*.log.gz', all members are scanned by default")
+	cmdScan.Flags().BoolVar(&Follow, "follow", false, "follow the log files like tail -F until SIGINT or SIGTERM, the truncated and rotated files are read from the beginning, and - reads the log from stdin")
+	cmdScan.Flags().DurationVar(&FlushInterval, "flush-interval", 10*time.Second, "the interval of flushing the log coverage into log pattern set while following logs, `logcov analyze` can read the coverage meanwhile")
+	cmdScan.Flags().IntVar(&SampleSize, "samples", 3, "the max number of raw log lines that are sampled for every log pattern, they are shown in reports to confirm the matches, 0 disables sampling but keeps the samples in log pattern set")
+	cmdScan.Flags().BoolVar(&ResetCoverage, "reset", false, "drop the log coverage and checkpoints in log pattern set before scanning, otherwise the coverage of logs is added to it and the logs scanned before are resumed from their checkpoints")
 	cmdScan.MarkFlagRequired("log-pattern")
 	cmdScan.MarkFlagRequired("logs")
 	return cmdScan
 }
 
-func ScanLog(storePath string, logs []string) {
+// logFiles expands the entries of --logs into log files, an entry may be a file, a directory that is walked recursively,
+// a glob, or @filelist that lists the entries line by line. The files found in directories and by globs are filtered
+// by includes and excludes, the globs without slash match file names. The parser of entry is forced for all its files
+// if the entry is prefixed by parser name, e.g. zap:dm-worker.log
+func logFiles(entries, includes, excludes []string) ([]string, error) {
+	var (
+		files []string
+		seen  = make(map[string]struct{})
+	)
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parser, entry := splitLogParser(entry)
+		expanded, err := expandLogEntry(entry, includes, excludes)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range expanded {
+			if parser != "" {
+				log_scan.ForceLogParser(file, parser)
+			}
+			if _, ok := seen[file]; !ok {
+				seen[file] = struct{}{}
+				files = append(files, file)
+			}
+		}
+	}
+	return files, nil
+}
+
+// followLogFiles returns the followed log files of the entries of --logs, the parser of file is forced
+// by the parser prefix like logFiles, but the directories, globs and @filelist are not expanded
+func followLogFiles(entries []string) []string {
+	var files []string
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parser, file := splitLogParser(entry)
+		if parser != "" {
+			log_scan.ForceLogParser(file, parser)
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// splitLogParser splits the entry of --logs into the parser name and path if it's prefixed by the name of registered parser
+func splitLogParser(entry string) (string, string) {
+	if i := strings.Index(entry, ":"); i > 0 && log_scan.HasLogParser(entry[:i]) {
+		return entry[:i], entry[i+1:]
+	}
+	return "", entry
+}
+
+func expandLogEntry(entry string, includes, excludes []string) ([]string, error) {
+	if strings.HasPrefix(entry, "@") {
+		content, err := ioutil.ReadFile(entry[1:])
+		if err != nil {
+			return nil, fmt.Errorf("read log file list %s failed: %v", entry[1:], err)
+		}
+
+		var entries []string
+		for _, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				entries = append(entries, line)
+			}
+		}
+		return logFiles(entries, includes, excludes)
+	}
+
+	if strings.ContainsAny(entry, "*?[") {
+		matches, err := filepath.Glob(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log glob %s: %v", entry, err)
+		}
+
+		var files []string
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if info.IsDir() {
+				walked, err := walkLogDir(match, includes, excludes)
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, walked...)
+			} else if info.Mode().IsRegular() && matchLogFile(match, includes, excludes) {
+				files = append(files, match)
+			}
+		}
+		return files, nil
+	}
+
+	if info, err := os.Stat(entry); err == nil && info.IsDir() {
+		return walkLogDir(entry, includes, excludes)
+	}
+	// the files listed explicitly are not filtered, and the files that can't be opened are reported by scanner
+	return []string{entry}, nil
+}
+
+// walkLogDir returns the regular files in dir and its subdirectories in lexical order, the hidden directories are skipped
+func walkLogDir(dir string, includes, excludes []string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != dir && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() && matchLogFile(path, includes, excludes) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// matchLogFile reports whether the file is matched by any include and not matched by excludes,
+// all files are included if there is no include
+func matchLogFile(path string, includes, excludes []string) bool {
+	match := func(globs []string) bool {
+		for _, glob := range globs {
+			name := path
+			if !strings.Contains(glob, "/") {
+				name = filepath.Base(path)
+			}
+			if ok, _ := filepath.Match(glob, name); ok {
+				return true
+			}
+		}
+		return false
+	}
+	return (len(includes) == 0 || match(includes)) && !match(excludes)
+}
+
+// jsonLogKeys returns the key names of JSON logs, the keys that are not set use the names of zap
+func jsonLogKeys(names map[string]string) (log_scan.JSONLogKeys, error) {
+	keys := log_scan.DefaultJSONLogKeys
+	for key, name := range names {
+		switch key {
+		case "level":
+			keys.Level = name
+		case "time":
+			keys.Time = name
+		case "caller":
+			keys.Caller = name
+		case "msg":
+			keys.Msg = name
+		default:
+			return keys, fmt.Errorf("unknown JSON log key %s, only level, time, caller and msg are supported", key)
+		}
+	}
+	return keys, nil
+}
+
+// logfmtLogKeys returns the key aliases of logfmt logs, the keys that are not set use the default aliases
+func logfmtLogKeys(names map[string]string) (log_scan.LogfmtKeys, error) {
+	var keys log_scan.LogfmtKeys
+	for key, name := range names {
+		aliases := strings.Split(name, "|")
+		switch key {
+		case "level":
+			keys.Level = aliases
+		case "time":
+			keys.Time = aliases
+		case "caller":
+			keys.Caller = aliases
+		case "msg":
+			keys.Msg = aliases
+		default:
+			return keys, fmt.Errorf("unknown logfmt log key %s, only level, time, caller and msg are supported", key)
+		}
+	}
+	return keys, nil
+}
+
+func ScanLog(storePath string, logs []string, reset bool) {
 	db, err := leveldb.Open(storePath, nil)
 	if err != nil {
 		log.Fatalf("open leveldb failed %v", err)
@@ -68,6 +341,12 @@ func ScanLog(storePath string, logs []string) {
 		log.Fatalf("get log pattern rule from store failed %v", err)
 	}
 
+	if reset {
+		if err := store.ResetCoverage(context.Background()); err != nil {
+			log.Fatalf("reset coverage failed %v", err)
+		}
+	}
+
 	p, err := log_scanner.NewLogProcessor(store, logs)
 	if err != nil {
 		log.Fatalf("new scanner pipeline failed %s", err)
@@ -77,4 +356,57 @@ func ScanLog(storePath string, logs []string) {
 	if err != nil {
 		log.Fatalf("scanner run %s", err)
 	}
+
+	for _, skipped := range p.Skipped() {
+		fmt.Printf("skip log %s: %s\n", skipped.Path, skipped.Reason)
+	}
+}
+
+// FollowLog follows the logs until SIGINT or SIGTERM, the coverage is flushed every flushInterval and on exit.
+// The log pattern set is only opened while flushing, so that it can be analyzed meanwhile
+func FollowLog(storePath string, files []string, flushInterval time.Duration, reset bool) {
+	db, err := leveldb.OpenReleasable(storePath, sharedStoreOptions)
+	if err != nil {
+		log.Fatalf("open leveldb failed %v", err)
+	}
+
+	store := keyvalue.NewLogPatternStore(db)
+
+	rule, err := util.GetLogPatternRule(store)
+	if err != nil {
+		log.Fatalf("get log pattern rule from store failed %v", err)
+	}
+
+	if reset {
+		if err := store.ResetCoverage(context.Background()); err != nil {
+			log.Fatalf("reset coverage failed %v", err)
+		}
+	}
+
+	// the readers stop on signal, and the processor drains the read logs and flushes the coverage
+	ctx, cancel := context.WithCancel(context.Background())
+	sc := make(chan os.Signal, 1)
+	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sc
+		log.Printf("got signal %v, stop following logs", sig)
+		cancel()
+	}()
+
+	p, err := log_scanner.NewFollowLogProcessor(ctx, store, files, flushInterval)
+	if err != nil {
+		log.Fatalf("new scanner pipeline failed %s", err)
+	}
+	if err := store.Release(); err != nil {
+		log.Fatalf("release leveldb failed %v", err)
+	}
+
+	err = p.Run(context.Background(), rule)
+	if err != nil {
+		log.Fatalf("scanner run %s", err)
+	}
+
+	for _, skipped := range p.Skipped() {
+		fmt.Printf("skip log %s: %s\n", skipped.Path, skipped.Reason)
+	}
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -74,3 +75,57 @@ func testLogScan(c *C, store *keyvalue.Store, logs []string) {
 
 	c.Assert(p.Run(context.Background(), rule), IsNil)
 }
+
+func (t *testLogScannerSuite) TestLogFiles(c *C) {
+	dir := c.MkDir()
+	for _, name := range []string{
+		"dm-master/dm-master.log",
+		"dm-worker-1/dm-worker.log",
+		"dm-worker-1/dm-worker.toml",
+		"dm-worker-1/.cache/dm-worker.log",
+		"dm-worker-2/log/dm-worker.log",
+		"tidb.log",
+	} {
+		path := filepath.Join(dir, name)
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0o755), IsNil)
+		c.Assert(ioutil.WriteFile(path, nil, 0o644), IsNil)
+	}
+	list := filepath.Join(dir, "logs.txt")
+	c.Assert(ioutil.WriteFile(list, []byte("# tidb logs\n"+filepath.Join(dir, "tidb.log")+"\n\nzap:"+filepath.Join(dir, "dm-master")+"\n"), 0o644), IsNil)
+
+	files, err := logFiles([]string{filepath.Join(dir, "dm-worker-*"), "@" + list, filepath.Join(dir, "tidb.log")}, nil, []string{"*.toml"})
+	c.Assert(err, IsNil)
+	for i := range files {
+		files[i], err = filepath.Rel(dir, files[i])
+		c.Assert(err, IsNil)
+	}
+	c.Assert(files, DeepEquals, []string{
+		"dm-worker-1/dm-worker.log",
+		"dm-worker-2/log/dm-worker.log",
+		"tidb.log",
+		"dm-master/dm-master.log",
+	})
+
+	// the explicit files are not filtered
+	files, err = logFiles([]string{dir, filepath.Join(dir, "dm-worker-1/dm-worker.toml")}, []string{"dm-worker.*"}, []string{"*.toml"})
+	c.Assert(err, IsNil)
+	c.Assert(files, DeepEquals, []string{
+		filepath.Join(dir, "dm-worker-1/dm-worker.log"),
+		filepath.Join(dir, "dm-worker-2/log/dm-worker.log"),
+		filepath.Join(dir, "dm-worker-1/dm-worker.toml"),
+	})
+
+	_, err = logFiles([]string{"@" + filepath.Join(dir, "missing.txt")}, nil, nil)
+	c.Assert(err, ErrorMatches, "read log file list .* failed.*")
+}
+
+func (t *testLogScannerSuite) TestFollowLogFiles(c *C) {
+	dir := c.MkDir()
+	files := followLogFiles([]string{"zap:" + filepath.Join(dir, "dm-worker.log"), " - ", "", filepath.Join(dir, "dm-*.log"), "unknown:tidb.log"})
+	c.Assert(files, DeepEquals, []string{
+		filepath.Join(dir, "dm-worker.log"),
+		"-",
+		filepath.Join(dir, "dm-*.log"),
+		"unknown:tidb.log",
+	})
+}
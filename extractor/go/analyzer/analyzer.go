@@ -7,63 +7,68 @@ import (
 	"go/types"
 
 	logpattern "github.com/IANTHEREAL/logutil/proto"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
 	"github.com/gogo/protobuf/proto"
 )
 
 // Analyzer used to analyze GO ast
 type Aanalyzer interface {
 	Run(*ast.File, *AstHelper)
-	SetupOutput() <-chan proto.Message
-	MarkDone()
 }
 
-// logAanalyzer used to find the log of interest
-type logAanalyzer struct {
-	// todo: add lock
-	logChan chan proto.Message
+// LogPatternSink receives the log patterns found by log pass
+type LogPatternSink func(*logpattern.LogPattern)
+
+// WrapperFunc reports whether fn is a log wrapper, which prints its argument at msgIndex as log message in level, e.g.
+//
+//	func logError(msg string) { log.Error(msg) }
+type WrapperFunc func(fn *types.Func) (level string, msgIndex int, ok bool)
+
+// logPass used to find the log of interest
+type logPass struct {
+	sink LogPatternSink
 
-	fn func(logPkg, logFn, logMessage string) (string, bool)
+	fn      func(logPkg, logFn, logMessage string) (string, bool)
+	wrapper WrapperFunc
 }
 
-func NewAstAnalyzer(fn func(logPkg, logFn, logMessage string) (string, bool)) *logAanalyzer {
-	return &logAanalyzer{fn: fn}
+// NewLogPass returns a pass that finds the log of interest, fn is used to filter log
+func NewLogPass(fn func(logPkg, logFn, logMessage string) (string, bool), sink LogPatternSink) Pass {
+	return &logPass{fn: fn, sink: sink}
 }
 
-func (ai *logAanalyzer) Run(file *ast.File, helper *AstHelper) {
-	ast.Walk(newASTVisitor(func(node ast.Node, stack stackFunc) bool {
-		switch n := node.(type) {
-		case *ast.BasicLit:
-			// try to filter log pattern
-			ai.filterLog(n, stack, helper)
-		}
-		return true
-	}), file)
+// NewLogPassWithWrappers returns a log pass that also finds the log printed through log wrappers
+func NewLogPassWithWrappers(fn func(logPkg, logFn, logMessage string) (string, bool), wrapper WrapperFunc, sink LogPatternSink) Pass {
+	return &logPass{fn: fn, wrapper: wrapper, sink: sink}
 }
 
-func (ai *logAanalyzer) SetupOutput() <-chan proto.Message {
-	ai.MarkDone()
+func (p *logPass) Name() string { return "log" }
 
-	ai.logChan = make(chan proto.Message, 256)
-	return ai.logChan
-}
+func (p *logPass) KeyPrefix() string { return keyvalue.LogPatternKeyPrefix }
 
-func (ai *logAanalyzer) MarkDone() {
-	if ai.logChan != nil {
-		close(ai.logChan)
+func (p *logPass) Visit(node ast.Node, stack stackFunc, helper *AstHelper) {
+	switch n := node.(type) {
+	case *ast.BasicLit:
+		// try to filter log pattern
+		p.filterLog(n, stack, helper)
+	case *ast.CallExpr:
+		if p.wrapper != nil {
+			p.matchWrapper(n, stack, helper)
+		}
 	}
 }
 
-func (ai *logAanalyzer) filterLog(id *ast.BasicLit, stack stackFunc, helper *AstHelper) {
-	switch p := stack(1).(type) {
+func (p *logPass) filterLog(id *ast.BasicLit, stack stackFunc, helper *AstHelper) {
+	switch pp := stack(1).(type) {
 	case *ast.Ident, *ast.SelectorExpr:
 	case *ast.CallExpr:
-		if pp, ok := p.Fun.(*ast.SelectorExpr); ok {
-			ai.matchLog(id, pp.Sel, stack, helper)
+		if sel, ok := pp.Fun.(*ast.SelectorExpr); ok {
+			p.matchLog(id, sel.Sel, stack, helper)
 		}
 	}
 }
 
-func (ai *logAanalyzer) matchLog(l *ast.BasicLit, fn *ast.Ident, stack stackFunc, helper *AstHelper) {
+func (p *logPass) matchLog(l *ast.BasicLit, fn *ast.Ident, stack stackFunc, helper *AstHelper) {
 	// get the log print
 	obj := helper.GetTypeUsed(fn)
 	if obj == nil {
@@ -72,50 +77,117 @@ func (ai *logAanalyzer) matchLog(l *ast.BasicLit, fn *ast.Ident, stack stackFunc
 	}
 
 	if _, ok := isCall(fn, obj, stack); ok {
-		callFnName, rawCallFnPos := ai.callContext(stack, helper)
-
 		fnName := obj.Name()
 		fnPkg := obj.Pkg().Name()
-		helper.GetPos(obj.Pos())
 
 		/*if strings.Contains(fnName, "Error") {
 			log.Printf("fnPkg %s %s %+v", fnPkg, fnName, obj.Type().(*types.Signature).Recv().Type())
 		}*/
 
-		if level, ok := ai.fn(fnPkg, fnName, l.Value); ok {
-			fnPos := helper.GetPos(rawCallFnPos)
-			fnProtoPos := &logpattern.Position{
-				FilePath:     fnPos.Filename,
-				LineNumber:   int32(fnPos.Line),
-				ColumnOffset: int32(fnPos.Offset),
-			}
-			logPos := helper.GetPos(l.Pos())
-			logProtoPos := &logpattern.Position{
-				FilePath:     logPos.Filename,
-				LineNumber:   int32(logPos.Line),
-				ColumnOffset: int32(logPos.Offset),
-			}
-
-			fn := &logpattern.FuncInfo{
-				Pos:  fnProtoPos,
-				Name: callFnName,
-			}
-
-			ai.logChan <- &logpattern.LogPattern{
-				Pos:       logProtoPos,
-				Func:      fn,
-				Level:     level,
-				Signature: []string{l.Value},
-			}
+		if level, ok := p.fn(fnPkg, fnName, l.Value); ok {
+			p.emit(l, level, stack, helper)
 		}
 	}
 	//log.Printf("done match log %+v", l)
 }
 
+// matchWrapper finds the log printed through log wrapper, e.g. logError("xxx failed")
+func (p *logPass) matchWrapper(call *ast.CallExpr, stack stackFunc, helper *AstHelper) {
+	var id *ast.Ident
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		id = fn
+	case *ast.SelectorExpr:
+		id = fn.Sel
+	default:
+		return
+	}
+
+	obj, ok := helper.GetTypeUsed(id).(*types.Func)
+	if !ok || obj.Pkg() == nil {
+		return
+	}
+	// the log functions are already matched by filterLog
+	if _, ok := p.fn(obj.Pkg().Name(), obj.Name(), ""); ok {
+		return
+	}
+
+	level, msgIndex, ok := p.wrapper(obj)
+	if !ok || msgIndex >= len(call.Args) {
+		return
+	}
+	if l, ok := call.Args[msgIndex].(*ast.BasicLit); ok && l.Kind == token.STRING {
+		p.emit(l, level, stack, helper)
+	}
+}
+
+func (p *logPass) emit(l *ast.BasicLit, level string, stack stackFunc, helper *AstHelper) {
+	callFnName, rawCallFnPos := callContext(stack, helper)
+
+	fnPos := helper.GetPos(rawCallFnPos)
+	fnProtoPos := &logpattern.Position{
+		FilePath:     fnPos.Filename,
+		LineNumber:   int32(fnPos.Line),
+		ColumnOffset: int32(fnPos.Offset),
+	}
+	logPos := helper.GetPos(l.Pos())
+	logProtoPos := &logpattern.Position{
+		FilePath:     logPos.Filename,
+		LineNumber:   int32(logPos.Line),
+		ColumnOffset: int32(logPos.Offset),
+	}
+
+	fn := &logpattern.FuncInfo{
+		Pos:  fnProtoPos,
+		Name: callFnName,
+	}
+
+	p.sink(&logpattern.LogPattern{
+		Pos:       logProtoPos,
+		Func:      fn,
+		Level:     level,
+		Signature: []string{l.Value},
+		Loop:      loopContext(stack),
+	})
+}
+
+// logAanalyzer used to find the log of interest, and send them to the output channel
+type logAanalyzer struct {
+	// todo: add lock
+	logChan chan proto.Message
+
+	pass Pass
+}
+
+func NewAstAnalyzer(fn func(logPkg, logFn, logMessage string) (string, bool)) *logAanalyzer {
+	ai := &logAanalyzer{}
+	ai.pass = NewLogPass(fn, func(lp *logpattern.LogPattern) {
+		ai.logChan <- lp
+	})
+	return ai
+}
+
+func (ai *logAanalyzer) Run(file *ast.File, helper *AstHelper) {
+	walk(file, helper, ai.pass)
+}
+
+func (ai *logAanalyzer) SetupOutput() <-chan proto.Message {
+	ai.MarkDone()
+
+	ai.logChan = make(chan proto.Message, 256)
+	return ai.logChan
+}
+
+func (ai *logAanalyzer) MarkDone() {
+	if ai.logChan != nil {
+		close(ai.logChan)
+	}
+}
+
 // callContext returns funcInfo for the nearest enclosing parent function, not
 // including the node itself, or the enclosing package initializer if the node
 // is at the top level.
-func (ai *logAanalyzer) callContext(stack stackFunc, helper *AstHelper) (string, token.Pos) {
+func callContext(stack stackFunc, helper *AstHelper) (string, token.Pos) {
 	for i := 1; ; i++ {
 		switch p := stack(i).(type) {
 		case *ast.FuncDecl:
@@ -0,0 +1,68 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+// Pass is an analysis that runs inside the shared AST walk of CompositeAnalyzer,
+// so that all analyses only pay for compiling and walking the codebase once
+type Pass interface {
+	// Name returns the unique name of the pass, e.g. log
+	Name() string
+	// KeyPrefix returns the store key prefix that the results of the pass are written under, e.g. log:,
+	// it's empty if the pass doesn't write into store
+	KeyPrefix() string
+	// Visit is called for every node of the file AST, stack returns the AST nodes
+	// on the path from the node up to the root
+	Visit(node ast.Node, stack stackFunc, helper *AstHelper)
+}
+
+// CompositeAnalyzer runs all registered passes in one ast.Walk per file
+type CompositeAnalyzer struct {
+	passes []Pass
+}
+
+func NewCompositeAnalyzer() *CompositeAnalyzer {
+	return &CompositeAnalyzer{}
+}
+
+// Register adds the pass into the analyzer, the name and the key prefix of the pass
+// must not conflict with the registered passes, otherwise their results would be mixed up in store
+func (c *CompositeAnalyzer) Register(pass Pass) error {
+	for _, p := range c.passes {
+		if p.Name() == pass.Name() {
+			return fmt.Errorf("pass %s is already registered", pass.Name())
+		}
+		if p.KeyPrefix() == "" || pass.KeyPrefix() == "" {
+			continue
+		}
+		if strings.HasPrefix(p.KeyPrefix(), pass.KeyPrefix()) || strings.HasPrefix(pass.KeyPrefix(), p.KeyPrefix()) {
+			return fmt.Errorf("key prefix %q of pass %s conflicts with key prefix %q of pass %s",
+				pass.KeyPrefix(), pass.Name(), p.KeyPrefix(), p.Name())
+		}
+	}
+
+	c.passes = append(c.passes, pass)
+	return nil
+}
+
+// Passes returns the registered passes in registration order
+func (c *CompositeAnalyzer) Passes() []Pass {
+	return c.passes
+}
+
+func (c *CompositeAnalyzer) Run(file *ast.File, helper *AstHelper) {
+	walk(file, helper, c.passes...)
+}
+
+// walk visits the file AST once and dispatches every node to all passes
+func walk(file *ast.File, helper *AstHelper, passes ...Pass) {
+	ast.Walk(newASTVisitor(func(node ast.Node, stack stackFunc) bool {
+		for _, p := range passes {
+			p.Visit(node, stack, helper)
+		}
+		return true
+	}), file)
+}
@@ -0,0 +1,345 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// DupLogStep is a function on the call chain that an error value is passed along
+type DupLogStep struct {
+	// Func is the full name of function, e.g. (*github.com/pingcap/ticdc/dm/dm/worker.Worker).Start
+	Func string
+	// Pos is the position of the log call if the error is logged in the function,
+	// otherwise it's the position of the call that the error is returned from
+	Pos    token.Position
+	Logged bool
+}
+
+// DupLogChain is a call chain along which one error is logged more than once,
+// the steps start from the function that logs and returns the error first
+// and end with the caller that logs it again
+type DupLogChain struct {
+	Steps []*DupLogStep
+}
+
+func (c *DupLogChain) String() string {
+	var b strings.Builder
+	for i, s := range c.Steps {
+		if i > 0 {
+			b.WriteString("\n\t-> ")
+		}
+		switch {
+		case i == 0:
+			fmt.Fprintf(&b, "%s: %s logs error and returns it", s.Pos, s.Func)
+		case s.Logged:
+			fmt.Fprintf(&b, "%s: %s logs it again", s.Pos, s.Func)
+		default:
+			fmt.Fprintf(&b, "%s: %s returns it", s.Pos, s.Func)
+		}
+	}
+	return b.String()
+}
+
+// errorUse is a use of error variable
+type errorUse struct {
+	obj types.Object
+	pos token.Pos
+}
+
+// errorAssign is an assignment to error variable, callee is empty if the value doesn't come from a function call
+type errorAssign struct {
+	pos     token.Pos
+	callee  string
+	callPos token.Position
+}
+
+// errorFunc records how a function handles error values
+type errorFunc struct {
+	name string
+
+	logs    []*errorUse
+	logPos  map[*errorUse]token.Position
+	returns []*errorUse
+	// the callees whose results are returned directly, e.g. return f()
+	returnCalls []*errorAssign
+	// the assignments to every error variable in source order
+	assigns map[types.Object][]*errorAssign
+}
+
+// sourceAt returns the last assignment to obj before pos
+func (f *errorFunc) sourceAt(obj types.Object, pos token.Pos) *errorAssign {
+	var source *errorAssign
+	for _, a := range f.assigns[obj] {
+		if a.pos >= pos {
+			break
+		}
+		source = a
+	}
+	return source
+}
+
+// errorOrigin tells where the error returned by a function is logged
+type errorOrigin struct {
+	step *DupLogStep
+	// next is the origin of callee if the function only returns the error logged by callee
+	next *errorOrigin
+}
+
+// DupLogPass finds the errors that are logged and returned, then logged again by the callers.
+// The call graph is built by AST and type info, so calls of interface methods and function values are not followed,
+// and the data flow of error variables is approximated in source order
+type DupLogPass struct {
+	fn    func(logPkg, logFn, logMessage string) (string, bool)
+	funcs map[string]*errorFunc
+}
+
+func NewDupLogPass(fn func(logPkg, logFn, logMessage string) (string, bool)) *DupLogPass {
+	return &DupLogPass{fn: fn, funcs: make(map[string]*errorFunc)}
+}
+
+func (p *DupLogPass) Name() string { return "duplog" }
+
+// KeyPrefix returns empty prefix, the chains are not written into store
+func (p *DupLogPass) KeyPrefix() string { return "" }
+
+func (p *DupLogPass) Visit(node ast.Node, stack stackFunc, helper *AstHelper) {
+	decl, ok := node.(*ast.FuncDecl)
+	if !ok || decl.Body == nil {
+		return
+	}
+	if _, ok := stack(1).(*ast.File); !ok {
+		return
+	}
+	obj, ok := helper.GetTypeDef(decl.Name).(*types.Func)
+	if !ok {
+		return
+	}
+
+	f := &errorFunc{
+		name:    obj.FullName(),
+		logPos:  make(map[*errorUse]token.Position),
+		assigns: make(map[types.Object][]*errorAssign),
+	}
+	p.inspect(f, decl.Body, false, helper)
+	p.funcs[f.name] = f
+}
+
+// inspect collects the error uses of function body, the returns in closures are not the returns of function
+func (p *DupLogPass) inspect(f *errorFunc, body ast.Node, inClosure bool, helper *AstHelper) {
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			p.inspect(f, n.Body, true, helper)
+			return false
+		case *ast.CallExpr:
+			if isLogCall(n, p.fn, helper) {
+				for _, obj := range errorVars(n.Args, helper) {
+					use := &errorUse{obj: obj, pos: n.Pos()}
+					f.logs = append(f.logs, use)
+					f.logPos[use] = helper.GetPos(n.Pos())
+				}
+			}
+		case *ast.ReturnStmt:
+			if inClosure {
+				return true
+			}
+			for _, obj := range errorVars(n.Results, helper) {
+				f.returns = append(f.returns, &errorUse{obj: obj, pos: n.Pos()})
+			}
+			for _, res := range n.Results {
+				if call, ok := res.(*ast.CallExpr); ok {
+					if callee, ok := calledFunc(call, helper); ok && returnsError(callee) {
+						f.returnCalls = append(f.returnCalls, &errorAssign{pos: n.Pos(), callee: callee.FullName(), callPos: helper.GetPos(call.Pos())})
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			p.assign(f, n.Lhs, n.Rhs, n.Pos(), helper)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, 0, len(n.Names))
+			for _, name := range n.Names {
+				lhs = append(lhs, name)
+			}
+			p.assign(f, lhs, n.Values, n.Pos(), helper)
+		}
+		return true
+	})
+}
+
+// assign records the assignments to error variables, e.g. err := f() or _, err = g()
+func (p *DupLogPass) assign(f *errorFunc, lhs, rhs []ast.Expr, pos token.Pos, helper *AstHelper) {
+	for i, l := range lhs {
+		id, ok := l.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		obj := helper.GetTypeDef(id)
+		if obj == nil {
+			obj = helper.GetTypeUsed(id)
+		}
+		if !isErrorVar(obj) {
+			continue
+		}
+
+		// the value is the i-th expr, or one result of the only call, e.g. _, err = g()
+		var value ast.Expr
+		if len(lhs) == len(rhs) {
+			value = rhs[i]
+		} else if len(rhs) == 1 {
+			value = rhs[0]
+		}
+
+		a := &errorAssign{pos: pos}
+		if call, ok := value.(*ast.CallExpr); ok {
+			if callee, ok := calledFunc(call, helper); ok {
+				a.callee = callee.FullName()
+				a.callPos = helper.GetPos(call.Pos())
+			}
+		}
+		f.assigns[obj] = append(f.assigns[obj], a)
+	}
+}
+
+// isLogCall reports whether call is a log statement matched by fn, the log functions return nothing,
+// which tells them from the field constructors of the same name, e.g. zap.Error
+func isLogCall(call *ast.CallExpr, fn func(logPkg, logFn, logMessage string) (string, bool), helper *AstHelper) bool {
+	obj, ok := calledFunc(call, helper)
+	if !ok || obj.Pkg() == nil {
+		return false
+	}
+	if sig, ok := obj.Type().(*types.Signature); !ok || sig.Results().Len() > 0 {
+		return false
+	}
+	_, ok = fn(obj.Pkg().Name(), obj.Name(), "")
+	return ok
+}
+
+// Chains returns the duplicate logging chains of all visited functions, sorted by the position of the last step
+func (p *DupLogPass) Chains() []*DupLogChain {
+	origins := p.origins()
+
+	var chains []*DupLogChain
+	for _, f := range p.funcs {
+		for _, l := range f.logs {
+			source := f.sourceAt(l.obj, l.pos)
+			if source == nil || source.callee == "" {
+				continue
+			}
+			origin := origins[source.callee]
+			if origin == nil {
+				continue
+			}
+
+			chain := &DupLogChain{}
+			for o := origin; o != nil; o = o.next {
+				chain.Steps = append([]*DupLogStep{o.step}, chain.Steps...)
+			}
+			chain.Steps = append(chain.Steps, &DupLogStep{Func: f.name, Pos: f.logPos[l], Logged: true})
+			chains = append(chains, chain)
+		}
+	}
+
+	sort.Slice(chains, func(i, j int) bool {
+		a, b := chains[i].Steps[len(chains[i].Steps)-1].Pos, chains[j].Steps[len(chains[j].Steps)-1].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return chains
+}
+
+// origins finds the functions that return logged errors until there are no more functions found,
+// a function returns a logged error if it logs the error and returns it, or returns the error from such a callee
+func (p *DupLogPass) origins() map[string]*errorOrigin {
+	origins := make(map[string]*errorOrigin)
+	for _, f := range p.funcs {
+		for _, r := range f.returns {
+			source := f.sourceAt(r.obj, r.pos)
+			for _, l := range f.logs {
+				if l.obj == r.obj && l.pos < r.pos && f.sourceAt(l.obj, l.pos) == source {
+					origins[f.name] = &errorOrigin{step: &DupLogStep{Func: f.name, Pos: f.logPos[l], Logged: true}}
+					break
+				}
+			}
+			if origins[f.name] != nil {
+				break
+			}
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, f := range p.funcs {
+			if origins[f.name] != nil {
+				continue
+			}
+
+			sources := f.returnCalls
+			for _, r := range f.returns {
+				if source := f.sourceAt(r.obj, r.pos); source != nil {
+					sources = append(sources, source)
+				}
+			}
+			for _, source := range sources {
+				if next := origins[source.callee]; source.callee != "" && next != nil {
+					origins[f.name] = &errorOrigin{step: &DupLogStep{Func: f.name, Pos: source.callPos}, next: next}
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return origins
+}
+
+// errorVars returns the error variables used in exprs
+func errorVars(exprs []ast.Expr, helper *AstHelper) []types.Object {
+	var objs []types.Object
+	seen := make(map[types.Object]struct{})
+	for _, expr := range exprs {
+		ast.Inspect(expr, func(node ast.Node) bool {
+			if _, ok := node.(*ast.FuncLit); ok {
+				return false
+			}
+			id, ok := node.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			obj := helper.GetTypeUsed(id)
+			if _, ok := seen[obj]; !ok && isErrorVar(obj) {
+				seen[obj] = struct{}{}
+				objs = append(objs, obj)
+			}
+			return true
+		})
+	}
+	return objs
+}
+
+// isErrorVar reports whether obj is a local variable that implements error
+func isErrorVar(obj types.Object) bool {
+	v, ok := obj.(*types.Var)
+	if !ok || v.IsField() || v.Pkg() == nil || v.Parent() == nil || v.Parent() == v.Pkg().Scope() {
+		return false
+	}
+	return types.Implements(v.Type(), errorType)
+}
+
+// returnsError reports whether the last result of fn is error
+func returnsError(fn *types.Func) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Results().Len() == 0 {
+		return false
+	}
+	return types.Implements(sig.Results().At(sig.Results().Len()-1).Type(), errorType)
+}
@@ -0,0 +1,88 @@
+package analyzer
+
+import (
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testDupLogSuite{})
+
+type testDupLogSuite struct {
+}
+
+const testDupLogSource = `package demo
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+func load() error {
+	err := errors.New("fail to read config")
+	if err != nil {
+		zap.L().Error("fail to load", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func prepare() error {
+	return load()
+}
+
+func start() error {
+	if err := prepare(); err != nil {
+		zap.L().Error("fail to start", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func run() {
+	err := start()
+	zap.L().Error("fail to run", zap.Error(err))
+
+	err = check()
+	zap.L().Error("fail to check", zap.Error(err))
+
+	err = stop()
+	zap.L().Error("fail to stop", zap.Error(err))
+}
+
+func check() error {
+	err := errors.New("check failed")
+	defer func() {
+		if err != nil {
+			zap.L().Info("check failed", zap.Error(err))
+		}
+	}()
+	return err
+}
+
+func stop() error {
+	return errors.New("stop failed")
+}
+`
+
+func (t *testDupLogSuite) TestDupLogChains(c *C) {
+	file, helper := testTypeCheck(c, "demo", testDupLogSource)
+
+	pass := NewDupLogPass(testLogFilter)
+	ai := NewCompositeAnalyzer()
+	c.Assert(ai.Register(pass), IsNil)
+	ai.Run(file, helper)
+
+	var chains []string
+	for _, chain := range pass.Chains() {
+		chains = append(chains, chain.String())
+	}
+	c.Assert(chains, DeepEquals, []string{
+		"demo:12:3: demo.load logs error and returns it\n" +
+			"\t-> demo:19:9: demo.prepare returns it\n" +
+			"\t-> demo:24:3: demo.start logs it again",
+		"demo:24:3: demo.start logs error and returns it\n" +
+			"\t-> demo:32:2: demo.run logs it again",
+		"demo:45:4: demo.check logs error and returns it\n" +
+			"\t-> demo:35:2: demo.run logs it again",
+	})
+}
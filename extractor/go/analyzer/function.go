@@ -0,0 +1,78 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+
+	logpattern "github.com/IANTHEREAL/logutil/proto"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+)
+
+// FuncInfoSink receives the functions found by function pass
+type FuncInfoSink func(*logpattern.FuncInfo)
+
+// funcPass used to record all function declarations with their line range,
+// so that the frames of stack traces in logs can be mapped onto functions
+type funcPass struct {
+	sink FuncInfoSink
+}
+
+func NewFuncPass(sink FuncInfoSink) Pass {
+	return &funcPass{sink: sink}
+}
+
+func (p *funcPass) Name() string { return "function" }
+
+func (p *funcPass) KeyPrefix() string { return keyvalue.FunctionKeyPrefix }
+
+func (p *funcPass) Visit(node ast.Node, stack stackFunc, helper *AstHelper) {
+	// only top-level function declarations, function literals have no name in stack traces
+	if fn, ok := node.(*ast.FuncDecl); ok {
+		if _, ok := stack(1).(*ast.File); ok {
+			p.sink(p.funcInfo(fn, helper))
+		}
+	}
+}
+
+func (p *funcPass) funcInfo(fn *ast.FuncDecl, helper *AstHelper) *logpattern.FuncInfo {
+	pos := helper.GetPos(fn.Pos())
+	end := helper.GetPos(fn.End())
+
+	return &logpattern.FuncInfo{
+		Name: funcName(fn),
+		Pos: &logpattern.Position{
+			FilePath:     pos.Filename,
+			LineNumber:   int32(pos.Line),
+			ColumnOffset: int32(pos.Offset),
+		},
+		EndLineNumber: int32(end.Line),
+	}
+}
+
+// funcName returns function name in the style of runtime stack traces without package path,
+// e.g. updateSourceStatus, (*SourceWorker).updateSourceStatus, SourceWorker.String
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+
+	recv := fn.Recv.List[0].Type
+	pointer := false
+	if star, ok := recv.(*ast.StarExpr); ok {
+		pointer = true
+		recv = star.X
+	}
+	// strip type parameters of generic receivers
+	if index, ok := recv.(*ast.IndexExpr); ok {
+		recv = index.X
+	}
+
+	name := "?"
+	if id, ok := recv.(*ast.Ident); ok {
+		name = id.Name
+	}
+	if pointer {
+		return fmt.Sprintf("(*%s).%s", name, fn.Name.Name)
+	}
+	return fmt.Sprintf("%s.%s", name, fn.Name.Name)
+}
@@ -0,0 +1,317 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// LintRule is a rule that checks the quality of log statements
+type LintRule struct {
+	Name string
+	Doc  string
+
+	check func(call *logCall) string
+}
+
+// LintRules are all the lint rules
+var LintRules = []*LintRule{
+	{
+		Name:  "error-without-err",
+		Doc:   "error level structured logs should carry the error by zap.Error field",
+		check: checkErrorField,
+	},
+	{
+		Name:  "message-concat",
+		Doc:   "log messages should not be built by string concatenation or fmt.Sprint, use fields instead",
+		check: checkMessageConcat,
+	},
+	{
+		Name:  "short-message",
+		Doc:   "log messages should not be empty or a single word",
+		check: checkShortMessage,
+	},
+	{
+		Name:  "verb-in-non-format",
+		Doc:   "log messages of non-format methods, e.g. zap Error, should not contain format verbs",
+		check: checkVerbInNonFormat,
+	},
+	{
+		Name:  "format-args",
+		Doc:   "the argument count of format methods, e.g. Errorf, should match the format verbs",
+		check: checkFormatArgs,
+	},
+}
+
+// LintDiagnostic is a problem of log statement found by lint rule
+type LintDiagnostic struct {
+	Pos     token.Position
+	Rule    string
+	Message string
+}
+
+func (d *LintDiagnostic) String() string {
+	return fmt.Sprintf("%s: %s (%s)", d.Pos, d.Message, d.Rule)
+}
+
+// LintDiagnosticSink receives the diagnostics found by lint pass
+type LintDiagnosticSink func(*LintDiagnostic)
+
+// logCall is a log statement that lint rules check
+type logCall struct {
+	call   *ast.CallExpr
+	fn     *types.Func
+	level  string
+	helper *AstHelper
+}
+
+// message returns the message argument, it's the first argument of log functions
+func (c *logCall) message() ast.Expr {
+	if len(c.call.Args) == 0 {
+		return nil
+	}
+	return c.call.Args[0]
+}
+
+// constMessage returns the message if it's a constant string
+func (c *logCall) constMessage() (string, bool) {
+	msg := c.message()
+	if msg == nil {
+		return "", false
+	}
+	value := c.helper.GetTypeInfo().Types[msg].Value
+	if value == nil || value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(value), true
+}
+
+// structured reports whether the log function takes zap fields, e.g. func (log *Logger) Error(msg string, fields ...Field)
+func (c *logCall) structured() bool {
+	sig, ok := c.fn.Type().(*types.Signature)
+	if !ok || !sig.Variadic() {
+		return false
+	}
+	last := sig.Params().At(sig.Params().Len() - 1).Type().(*types.Slice).Elem()
+	named, ok := last.(*types.Named)
+	return ok && named.Obj().Name() == "Field" && named.Obj().Pkg() != nil && named.Obj().Pkg().Name() == "zap"
+}
+
+// formatted reports whether the log function takes format string, e.g. Errorf
+func (c *logCall) formatted() bool {
+	return strings.HasSuffix(c.fn.Name(), "f")
+}
+
+// lintPass used to check the quality of log statements
+type lintPass struct {
+	fn    func(logPkg, logFn, logMessage string) (string, bool)
+	rules []*LintRule
+	sink  LintDiagnosticSink
+}
+
+// NewLintPass returns a pass that checks the log statements filtered by fn with rules,
+// all rules are used if no rule names are given
+func NewLintPass(fn func(logPkg, logFn, logMessage string) (string, bool), ruleNames []string, sink LintDiagnosticSink) (Pass, error) {
+	p := &lintPass{fn: fn, sink: sink}
+	if len(ruleNames) == 0 {
+		p.rules = LintRules
+		return p, nil
+	}
+
+	for _, name := range ruleNames {
+		var rule *LintRule
+		for _, r := range LintRules {
+			if r.Name == name {
+				rule = r
+			}
+		}
+		if rule == nil {
+			return nil, fmt.Errorf("lint rule %s doesn't exist", name)
+		}
+		p.rules = append(p.rules, rule)
+	}
+	return p, nil
+}
+
+func (p *lintPass) Name() string { return "lint" }
+
+// KeyPrefix returns empty prefix, lint diagnostics are not written into store
+func (p *lintPass) KeyPrefix() string { return "" }
+
+func (p *lintPass) Visit(node ast.Node, stack stackFunc, helper *AstHelper) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+
+	obj, ok := calledFunc(call, helper)
+	if !ok || obj.Pkg() == nil {
+		return
+	}
+	level, ok := p.fn(obj.Pkg().Name(), obj.Name(), "")
+	if !ok {
+		return
+	}
+
+	lc := &logCall{call: call, fn: obj, level: level, helper: helper}
+	for _, rule := range p.rules {
+		if msg := rule.check(lc); msg != "" {
+			p.sink(&LintDiagnostic{
+				Pos:     helper.GetPos(call.Pos()),
+				Rule:    rule.Name,
+				Message: msg,
+			})
+		}
+	}
+}
+
+func checkErrorField(c *logCall) string {
+	if !c.structured() || (c.level != "error" && c.level != "fatal") {
+		return ""
+	}
+	if c.call.Ellipsis.IsValid() {
+		// fields are passed by slice
+		return ""
+	}
+
+	for _, arg := range c.call.Args[1:] {
+		if field, ok := arg.(*ast.CallExpr); ok {
+			if fn, ok := calledFunc(field, c.helper); ok && fn.Pkg() != nil && fn.Pkg().Name() == "zap" &&
+				(fn.Name() == "Error" || fn.Name() == "NamedError" || fn.Name() == "Errors") {
+				return ""
+			}
+		}
+	}
+	return fmt.Sprintf("%s level log %s has no zap.Error field", c.level, c.fn.Name())
+}
+
+func checkMessageConcat(c *logCall) string {
+	msg := c.message()
+	if msg == nil {
+		return ""
+	}
+	if _, ok := c.constMessage(); ok {
+		return ""
+	}
+
+	for {
+		paren, ok := msg.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+		msg = paren.X
+	}
+
+	switch m := msg.(type) {
+	case *ast.BinaryExpr:
+		if m.Op == token.ADD {
+			return "log message is built by string concatenation"
+		}
+	case *ast.CallExpr:
+		if fn, ok := calledFunc(m, c.helper); ok && c.structured() && fn.Pkg() != nil && fn.Pkg().Path() == "fmt" && strings.HasPrefix(fn.Name(), "Sprint") {
+			return fmt.Sprintf("log message is built by fmt.%s", fn.Name())
+		}
+	}
+	return ""
+}
+
+func checkShortMessage(c *logCall) string {
+	msg, ok := c.constMessage()
+	if !ok || c.formatted() && strings.Contains(msg, "%") {
+		return ""
+	}
+
+	switch words := len(strings.Fields(msg)); {
+	case words == 0:
+		return "log message is empty"
+	case words == 1:
+		return fmt.Sprintf("log message %q is a single word", msg)
+	}
+	return ""
+}
+
+func checkVerbInNonFormat(c *logCall) string {
+	if c.formatted() {
+		return ""
+	}
+	msg, ok := c.constMessage()
+	if !ok {
+		return ""
+	}
+
+	if n, _ := countFormatVerbs(msg); n > 0 {
+		return fmt.Sprintf("log message of non-format method %s contains format verbs", c.fn.Name())
+	}
+	return ""
+}
+
+func checkFormatArgs(c *logCall) string {
+	if !c.formatted() || c.call.Ellipsis.IsValid() {
+		return ""
+	}
+	format, ok := c.constMessage()
+	if !ok {
+		return ""
+	}
+
+	verbs, ok := countFormatVerbs(format)
+	if !ok {
+		return ""
+	}
+	if args := len(c.call.Args) - 1; args != verbs {
+		return fmt.Sprintf("%s format needs %d args but has %d args", c.fn.Name(), verbs, args)
+	}
+	return ""
+}
+
+// countFormatVerbs returns the count of arguments that format verbs consume, e.g. %*d consumes 2 arguments,
+// it returns false if the format uses explicit argument indexes
+func countFormatVerbs(format string) (int, bool) {
+	count := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		if i < len(format) && format[i] == '%' {
+			continue
+		}
+
+		// flags, width and precision
+		for ; i < len(format); i++ {
+			c := format[i]
+			if c == '[' {
+				return count, false
+			}
+			if c == '*' {
+				count++
+				continue
+			}
+			if strings.IndexByte("+-# 0123456789.", c) < 0 {
+				break
+			}
+		}
+		if i < len(format) {
+			count++
+		}
+	}
+	return count, true
+}
+
+// calledFunc returns the function called by call
+func calledFunc(call *ast.CallExpr, helper *AstHelper) (*types.Func, bool) {
+	var id *ast.Ident
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		id = fn
+	case *ast.SelectorExpr:
+		id = fn.Sel
+	default:
+		return nil, false
+	}
+	fn, ok := helper.GetTypeUsed(id).(*types.Func)
+	return fn, ok
+}
@@ -0,0 +1,133 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"testing"
+
+	. "github.com/pingcap/check"
+)
+
+func TestClient(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testLintSuite{})
+
+type testLintSuite struct {
+}
+
+const testZapSource = `package zap
+
+type Field struct{}
+
+type Logger struct{}
+
+func (log *Logger) Error(msg string, fields ...Field) {}
+func (log *Logger) Info(msg string, fields ...Field)  {}
+
+type SugaredLogger struct{}
+
+func (s *SugaredLogger) Errorf(template string, args ...interface{}) {}
+
+func Error(err error) Field                  { return Field{} }
+func String(key string, val string) Field    { return Field{} }
+func L() *Logger                             { return &Logger{} }
+func S() *SugaredLogger                      { return &SugaredLogger{} }
+`
+
+const testLintSource = `package demo
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+func run(name string, err error) {
+	zap.L().Error("failed to run task", zap.Error(err))
+	zap.L().Error("failed to run task", zap.String("name", name))
+	zap.L().Info("run task " + name)
+	zap.L().Info(fmt.Sprintf("run task %s", name))
+	zap.L().Info("")
+	zap.L().Info("done")
+	zap.L().Info("run task %s", zap.String("name", name))
+	zap.S().Errorf("run task %s failed: %v", name)
+	zap.S().Errorf("run task %s failed: %*d%%", name, 3, 10)
+	zap.S().Errorf("run task %[1]s failed", name)
+	fields := []zap.Field{zap.Error(err)}
+	zap.L().Error("failed to run task", fields...)
+}
+`
+
+// testImporter imports the fake zap package, and the other packages from source
+type testImporter struct {
+	zap      *types.Package
+	fallback types.Importer
+}
+
+func (i *testImporter) Import(path string) (*types.Package, error) {
+	if path == "go.uber.org/zap" {
+		return i.zap, nil
+	}
+	return i.fallback.Import(path)
+}
+
+// testTypeCheck parses and type checks the source of package,
+// the package go.uber.org/zap is imported from the fake zap source
+func testTypeCheck(c *C, path, src string) (*ast.File, *AstHelper) {
+	fset := token.NewFileSet()
+	check := func(path, src string, imp types.Importer) (*types.Package, *ast.File, *types.Info) {
+		file, err := parser.ParseFile(fset, path, src, 0)
+		c.Assert(err, IsNil)
+		info := &types.Info{
+			Types: make(map[ast.Expr]types.TypeAndValue),
+			Defs:  make(map[*ast.Ident]types.Object),
+			Uses:  make(map[*ast.Ident]types.Object),
+		}
+		pkg, err := (&types.Config{Importer: imp}).Check(path, fset, []*ast.File{file}, info)
+		c.Assert(err, IsNil)
+		return pkg, file, info
+	}
+
+	zapPkg, _, _ := check("go.uber.org/zap", testZapSource, nil)
+	pkg, file, info := check(path, src, &testImporter{zap: zapPkg, fallback: importer.ForCompiler(fset, "source", nil)})
+	return file, NewAstHelper(pkg, fset, info)
+}
+
+func testLogFilter(logPkg, logFn, logMessage string) (string, bool) {
+	levels := map[string]string{"Error": "error", "Errorf": "error", "Info": "info"}
+	level, ok := levels[logFn]
+	return level, ok && logPkg == "zap"
+}
+
+func (t *testLintSuite) TestLint(c *C) {
+	file, helper := testTypeCheck(c, "demo", testLintSource)
+
+	var diagnostics []string
+	pass, err := NewLintPass(testLogFilter, nil, func(d *LintDiagnostic) {
+		diagnostics = append(diagnostics, d.String())
+	})
+	c.Assert(err, IsNil)
+	ai := NewCompositeAnalyzer()
+	c.Assert(ai.Register(pass), IsNil)
+	ai.Run(file, helper)
+
+	sort.Strings(diagnostics)
+	c.Assert(diagnostics, DeepEquals, []string{
+		"demo:11:2: error level log Error has no zap.Error field (error-without-err)",
+		"demo:12:2: log message is built by string concatenation (message-concat)",
+		"demo:13:2: log message is built by fmt.Sprintf (message-concat)",
+		"demo:14:2: log message is empty (short-message)",
+		"demo:15:2: log message \"done\" is a single word (short-message)",
+		"demo:16:2: log message of non-format method Info contains format verbs (verb-in-non-format)",
+		"demo:17:2: Errorf format needs 2 args but has 1 args (format-args)",
+	})
+
+	_, err = NewLintPass(testLogFilter, []string{"short-message", "no-such-rule"}, nil)
+	c.Assert(err, ErrorMatches, "lint rule no-such-rule doesn't exist")
+}
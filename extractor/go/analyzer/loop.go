@@ -0,0 +1,212 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	logpattern "github.com/IANTHEREAL/logutil/proto"
+)
+
+// guardFuncs are the method names of rate limiters and samplers,
+// e.g. (*rate.Limiter).Allow, that guard the logs in the if conditions
+var guardFuncs = map[string]struct{}{
+	"Allow":     {},
+	"AllowN":    {},
+	"Sample":    {},
+	"Sampled":   {},
+	"ShouldLog": {},
+	"Every":     {},
+}
+
+// loopContext returns the loops around the node on the stack until the enclosing function declaration,
+// the closures in the loops are counted too, e.g. go func() { log.Error(...) }() in a for loop.
+// It returns nil if the node isn't in loops
+func loopContext(stack stackFunc) *logpattern.LoopInfo {
+	var (
+		depth          int
+		guarded, guard bool
+	)
+
+	child := stack(0)
+	for i := 1; ; i++ {
+		switch p := stack(i).(type) {
+		case nil, *ast.FuncDecl:
+			if depth == 0 {
+				return nil
+			}
+			return &logpattern.LoopInfo{Depth: int32(depth), Guarded: guarded}
+		case *ast.ForStmt:
+			if child == p.Body {
+				depth++
+				guarded = guarded || guard
+			}
+		case *ast.RangeStmt:
+			if child == p.Body {
+				depth++
+				guarded = guarded || guard
+			}
+		case *ast.IfStmt:
+			if child == p.Body && isGuardCond(p.Cond) {
+				guard = true
+			}
+		}
+		child = stack(i)
+	}
+}
+
+// isGuardCond reports whether the if condition is a rate limiter or sampling check,
+// e.g. limiter.Allow() or i%100 == 0
+func isGuardCond(cond ast.Expr) bool {
+	guard := false
+	ast.Inspect(cond, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
+				if _, ok := guardFuncs[sel.Sel.Name]; ok {
+					guard = true
+				}
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.REM {
+				guard = true
+			}
+		}
+		return !guard
+	})
+	return guard
+}
+
+// loopCall is a call of function in the same package
+type loopCall struct {
+	caller string
+	loop   *logpattern.LoopInfo
+}
+
+// outerLoop is the loops around all calls of a function
+type outerLoop struct {
+	depth   int32
+	guarded bool
+	via     []string
+}
+
+// LoopPass finds the calls of functions in the same package, so that the logs
+// in the callees of loops can be found after all files are visited
+type LoopPass struct {
+	// the function full names by the position of function declaration
+	funcs map[string]string
+	// the calls by callee full name
+	calls map[string][]*loopCall
+
+	outer map[string]*outerLoop
+}
+
+func NewLoopPass() *LoopPass {
+	return &LoopPass{
+		funcs: make(map[string]string),
+		calls: make(map[string][]*loopCall),
+	}
+}
+
+func (p *LoopPass) Name() string { return "loop" }
+
+// KeyPrefix returns empty prefix, the loops are written into store by log patterns
+func (p *LoopPass) KeyPrefix() string { return "" }
+
+func (p *LoopPass) Visit(node ast.Node, stack stackFunc, helper *AstHelper) {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		if obj, ok := helper.GetTypeDef(n.Name).(*types.Func); ok {
+			p.funcs[funcPosKey(helper.GetPos(n.Pos()))] = obj.FullName()
+		}
+	case *ast.CallExpr:
+		callee, ok := calledFunc(n, helper)
+		if !ok || callee.Pkg() != helper.GetPackage() {
+			return
+		}
+		for i := 1; ; i++ {
+			switch fn := stack(i).(type) {
+			case nil:
+				return
+			case *ast.FuncDecl:
+				caller, ok := helper.GetTypeDef(fn.Name).(*types.Func)
+				if ok && caller.FullName() != callee.FullName() {
+					p.calls[callee.FullName()] = append(p.calls[callee.FullName()], &loopCall{
+						caller: caller.FullName(),
+						loop:   loopContext(stack),
+					})
+				}
+				return
+			}
+		}
+	}
+}
+
+// Annotate adds the loops around the calls of the enclosing function into the loop info of pattern,
+// it should be called after all files of package are visited, and returns whether the pattern is changed
+func (p *LoopPass) Annotate(pattern *logpattern.LogPattern) bool {
+	if pattern.Func == nil || pattern.Func.Pos == nil {
+		return false
+	}
+	name, ok := p.funcs[funcPosKey(token.Position{Filename: pattern.Func.Pos.FilePath, Offset: int(pattern.Func.Pos.ColumnOffset)})]
+	if !ok {
+		return false
+	}
+
+	if p.outer == nil {
+		p.outer = make(map[string]*outerLoop)
+	}
+	outer := p.outerLoop(name, make(map[string]bool))
+	if outer == nil {
+		return false
+	}
+
+	if pattern.Loop == nil {
+		pattern.Loop = &logpattern.LoopInfo{}
+	}
+	pattern.Loop.Depth += outer.depth
+	pattern.Loop.Guarded = pattern.Loop.Guarded || outer.guarded
+	pattern.Loop.Via = append(append([]string{}, outer.via...), name)
+	return true
+}
+
+// outerLoop returns the deepest loops around the calls of function along the call chains, or nil if there are no loops
+func (p *LoopPass) outerLoop(name string, visiting map[string]bool) *outerLoop {
+	if outer, ok := p.outer[name]; ok {
+		return outer
+	}
+	if visiting[name] {
+		// recursive calls
+		return nil
+	}
+	visiting[name] = true
+	defer delete(visiting, name)
+
+	var res *outerLoop
+	for _, call := range p.calls[name] {
+		depth, guarded := int32(0), false
+		if call.loop != nil {
+			depth, guarded = call.loop.Depth, call.loop.Guarded
+		}
+		via := []string{call.caller}
+		if outer := p.outerLoop(call.caller, visiting); outer != nil {
+			depth += outer.depth
+			guarded = guarded || outer.guarded
+			via = append(append([]string{}, outer.via...), call.caller)
+		}
+		if depth > 0 && (res == nil || depth > res.depth) {
+			res = &outerLoop{depth: depth, guarded: guarded, via: via}
+		}
+	}
+
+	// results found during recursive calls are incomplete, so only the top level result is memorized
+	if len(visiting) == 1 {
+		p.outer[name] = res
+	}
+	return res
+}
+
+func funcPosKey(pos token.Position) string {
+	return fmt.Sprintf("%s:%d", pos.Filename, pos.Offset)
+}
@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"fmt"
+
+	logpattern "github.com/IANTHEREAL/logutil/proto"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testLoopSuite{})
+
+type testLoopSuite struct {
+}
+
+const testLoopSource = `package demo
+
+import (
+	"go.uber.org/zap"
+)
+
+type limiter struct{}
+
+func (l *limiter) Allow() bool { return true }
+
+func run(rows []string, l *limiter) {
+	for _, row := range rows {
+		zap.L().Info("process row", zap.String("row", row))
+		for i := 0; i < 10; i++ {
+			if l.Allow() {
+				zap.L().Info("retry row", zap.String("row", row))
+			}
+		}
+		process(row)
+	}
+	zap.L().Info("all rows are processed")
+}
+
+func process(row string) {
+	zap.L().Info("process row in callee", zap.String("row", row))
+	check(row)
+}
+
+func check(row string) {
+	for i := 0; i < len(row); i++ {
+		if i%100 == 0 {
+			zap.L().Info("check row", zap.String("row", row))
+		}
+	}
+}
+`
+
+func (t *testLoopSuite) TestLoop(c *C) {
+	file, helper := testTypeCheck(c, "demo", testLoopSource)
+
+	var patterns []*logpattern.LogPattern
+	loops := NewLoopPass()
+	ai := NewCompositeAnalyzer()
+	c.Assert(ai.Register(NewLogPass(testLogFilter, func(pattern *logpattern.LogPattern) {
+		patterns = append(patterns, pattern)
+	})), IsNil)
+	c.Assert(ai.Register(loops), IsNil)
+	ai.Run(file, helper)
+
+	var res []string
+	for _, pattern := range patterns {
+		loops.Annotate(pattern)
+		loop := pattern.GetLoop()
+		res = append(res, fmt.Sprintf("%s depth %d guarded %v via %v", pattern.Signature[0], loop.GetDepth(), loop.GetGuarded(), loop.GetVia()))
+	}
+	c.Assert(res, DeepEquals, []string{
+		`"process row" depth 1 guarded false via []`,
+		`"retry row" depth 2 guarded true via []`,
+		`"all rows are processed" depth 0 guarded false via []`,
+		`"process row in callee" depth 1 guarded false via [demo.run demo.process]`,
+		`"check row" depth 2 guarded true via [demo.run demo.process demo.check]`,
+	})
+}
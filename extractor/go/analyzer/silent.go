@@ -0,0 +1,215 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	logpattern "github.com/IANTHEREAL/logutil/proto"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+)
+
+const (
+	BoundaryGRPC      = "grpc"
+	BoundaryGoroutine = "goroutine"
+	BoundaryMain      = "main"
+)
+
+// SilentErrorPathSink receives the silent error paths found by silent pass
+type SilentErrorPathSink func(*logpattern.SilentErrorPath)
+
+// silentPass used to find the error checked branches of boundary functions that return without logging,
+// the errors returned by boundary functions are not logged by any caller in the codebase.
+// The boundary functions are gRPC handlers, goroutine entries started by go func() {...}() and main
+type silentPass struct {
+	fn   func(logPkg, logFn, logMessage string) (string, bool)
+	sink SilentErrorPathSink
+}
+
+// NewSilentErrorPass returns a pass that finds silent error paths, fn is used to find the log calls of all levels
+func NewSilentErrorPass(fn func(logPkg, logFn, logMessage string) (string, bool), sink SilentErrorPathSink) Pass {
+	return &silentPass{fn: fn, sink: sink}
+}
+
+func (p *silentPass) Name() string { return "silent" }
+
+func (p *silentPass) KeyPrefix() string { return keyvalue.SilentErrorPathKeyPrefix }
+
+func (p *silentPass) Visit(node ast.Node, stack stackFunc, helper *AstHelper) {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		if _, ok := stack(1).(*ast.File); !ok || n.Body == nil {
+			return
+		}
+		if boundary := boundaryKind(n, helper); boundary != "" {
+			p.check(n.Body, newFuncInfo(funcName(n), n.Pos(), helper), boundary, helper)
+		}
+	case *ast.GoStmt:
+		if lit, ok := n.Call.Fun.(*ast.FuncLit); ok {
+			name := "<init>"
+			for i := 1; stack(i) != nil; i++ {
+				if decl, ok := stack(i).(*ast.FuncDecl); ok {
+					name = funcName(decl)
+					break
+				}
+			}
+			p.check(lit.Body, newFuncInfo(name+".goroutine", lit.Pos(), helper), BoundaryGoroutine, helper)
+		}
+	}
+}
+
+// check finds the silent error paths in the body of boundary function, the closures are skipped
+// because their returns don't leave the boundary function
+func (p *silentPass) check(body *ast.BlockStmt, fn *logpattern.FuncInfo, boundary string, helper *AstHelper) {
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.IfStmt:
+			cond := errorCond(n.Cond, helper)
+			if cond == nil || !exits(n.Body, helper) || p.reports(n.Body, helper) {
+				return true
+			}
+
+			pos := helper.GetPos(n.Pos())
+			p.sink(&logpattern.SilentErrorPath{
+				Pos: &logpattern.Position{
+					FilePath:     pos.Filename,
+					LineNumber:   int32(pos.Line),
+					ColumnOffset: int32(pos.Offset),
+				},
+				Func:     fn,
+				Boundary: boundary,
+				Cond:     types.ExprString(cond),
+			})
+		}
+		return true
+	})
+}
+
+// reports reports whether there is a log call, or a print call of fmt package in the branch
+func (p *silentPass) reports(body *ast.BlockStmt, helper *AstHelper) bool {
+	found := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || found {
+			return !found
+		}
+		if isLogCall(call, p.fn, helper) {
+			found = true
+		} else if obj, ok := calledFunc(call, helper); ok && obj.Pkg() != nil && obj.Pkg().Path() == "fmt" &&
+			(strings.HasPrefix(obj.Name(), "Print") || strings.HasPrefix(obj.Name(), "Fprint")) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// errorCond returns the check of error in the if condition, e.g. err != nil
+func errorCond(cond ast.Expr, helper *AstHelper) ast.Expr {
+	var res ast.Expr
+	ast.Inspect(cond, func(node ast.Node) bool {
+		if _, ok := node.(*ast.FuncLit); ok || res != nil {
+			return false
+		}
+		bin, ok := node.(*ast.BinaryExpr)
+		if !ok || bin.Op != token.NEQ {
+			return true
+		}
+		for _, pair := range [][2]ast.Expr{{bin.X, bin.Y}, {bin.Y, bin.X}} {
+			if id, ok := pair[1].(*ast.Ident); !ok || id.Name != "nil" {
+				continue
+			}
+			if tv, ok := helper.GetTypeInfo().Types[pair[0]]; ok && tv.Type != nil && types.Implements(tv.Type, errorType) {
+				res = bin
+			}
+		}
+		return res == nil
+	})
+	return res
+}
+
+// exits reports whether the branch leaves the function, e.g. return err or os.Exit(1)
+func exits(body *ast.BlockStmt, helper *AstHelper) bool {
+	if len(body.List) == 0 {
+		return false
+	}
+
+	switch last := body.List[len(body.List)-1].(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.ExprStmt:
+		if call, ok := last.X.(*ast.CallExpr); ok {
+			obj, ok := calledFunc(call, helper)
+			return ok && obj.Pkg() != nil && obj.Pkg().Path() == "os" && obj.Name() == "Exit"
+		}
+	}
+	return false
+}
+
+// boundaryKind returns the kind of boundary function, or empty if it isn't a boundary function
+func boundaryKind(decl *ast.FuncDecl, helper *AstHelper) string {
+	obj, ok := helper.GetTypeDef(decl.Name).(*types.Func)
+	if !ok {
+		return ""
+	}
+	if decl.Recv == nil && decl.Name.Name == "main" && helper.GetPackage().Name() == "main" {
+		return BoundaryMain
+	}
+	if decl.Recv != nil && obj.Exported() && isGRPCHandler(obj.Type().(*types.Signature)) {
+		return BoundaryGRPC
+	}
+	return ""
+}
+
+// isGRPCHandler reports whether the method is a gRPC unary handler, e.g. Start(context.Context, *pb.StartRequest) (*pb.StartResponse, error),
+// or a gRPC streaming handler, e.g. Watch(*pb.WatchRequest, pb.Worker_WatchServer) error
+func isGRPCHandler(sig *types.Signature) bool {
+	params, results := sig.Params(), sig.Results()
+	if results.Len() == 0 || !types.Identical(results.At(results.Len()-1).Type(), types.Universe.Lookup("error").Type()) {
+		return false
+	}
+
+	if params.Len() == 2 && results.Len() == 2 {
+		return isNamed(params.At(0).Type(), "context", "Context") &&
+			isProtoMessage(params.At(1).Type()) && isProtoMessage(results.At(0).Type())
+	}
+	if params.Len() >= 1 && results.Len() == 1 {
+		stream := params.At(params.Len() - 1).Type()
+		return hasMethod(stream, "SendMsg") && hasMethod(stream, "RecvMsg")
+	}
+	return false
+}
+
+func isNamed(t types.Type, pkgPath, name string) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == pkgPath && named.Obj().Name() == name
+}
+
+// isProtoMessage reports whether t is a pointer of generated protobuf message
+func isProtoMessage(t types.Type) bool {
+	if _, ok := t.(*types.Pointer); !ok {
+		return false
+	}
+	return hasMethod(t, "ProtoMessage")
+}
+
+func hasMethod(t types.Type, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, name)
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
+func newFuncInfo(name string, pos token.Pos, helper *AstHelper) *logpattern.FuncInfo {
+	fnPos := helper.GetPos(pos)
+	return &logpattern.FuncInfo{
+		Name: name,
+		Pos: &logpattern.Position{
+			FilePath:     fnPos.Filename,
+			LineNumber:   int32(fnPos.Line),
+			ColumnOffset: int32(fnPos.Offset),
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package analyzer
+
+import (
+	"fmt"
+
+	logpattern "github.com/IANTHEREAL/logutil/proto"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testSilentSuite{})
+
+type testSilentSuite struct {
+}
+
+const testSilentSource = `package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+type StartRequest struct{}
+
+func (r *StartRequest) ProtoMessage() {}
+
+type StartResponse struct{}
+
+func (r *StartResponse) ProtoMessage() {}
+
+type Worker struct{}
+
+func (w *Worker) Start(ctx context.Context, req *StartRequest) (*StartResponse, error) {
+	if err := w.prepare(); err != nil {
+		return nil, err
+	}
+	if err := w.prepare(); err != nil {
+		zap.L().Info("fail to prepare", zap.Error(err))
+		return nil, err
+	}
+	go func() {
+		if err := w.prepare(); err != nil {
+			return
+		}
+	}()
+	return &StartResponse{}, nil
+}
+
+func (w *Worker) prepare() error {
+	if err := errors.New("not ready"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func main() {
+	w := &Worker{}
+	if _, err := w.Start(context.Background(), &StartRequest{}); err != nil {
+		os.Exit(1)
+	}
+	if err := w.prepare(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+`
+
+func (t *testSilentSuite) TestSilentErrorPaths(c *C) {
+	file, helper := testTypeCheck(c, "demo", testSilentSource)
+
+	var paths []string
+	ai := NewCompositeAnalyzer()
+	c.Assert(ai.Register(NewSilentErrorPass(testLogFilter, func(path *logpattern.SilentErrorPath) {
+		paths = append(paths, fmt.Sprintf("%d: %s in %s %s", path.Pos.LineNumber, path.Cond, path.Boundary, path.Func.Name))
+	})), IsNil)
+	ai.Run(file, helper)
+
+	c.Assert(paths, DeepEquals, []string{
+		"23: err != nil in grpc (*Worker).Start",
+		"31: err != nil in goroutine (*Worker).Start.goroutine",
+		"47: err != nil in main main",
+	})
+}
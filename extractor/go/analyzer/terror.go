@@ -0,0 +1,144 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+	"unicode"
+
+	logpattern "github.com/IANTHEREAL/logutil/proto"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+)
+
+// ErrorCodeSink receives the error codes found by terror pass
+type ErrorCodeSink func(*logpattern.ErrorCode)
+
+// terrorPass used to find the error codes registered by pingcap terror package, e.g.
+// ErrWorkerNoStart = New(codeWorkerNoStart, ClassDMWorker, ScopeInternal, LevelHigh, "no mysql source is being handled in the worker", "")
+type terrorPass struct {
+	sink ErrorCodeSink
+}
+
+func NewTerrorPass(sink ErrorCodeSink) Pass {
+	return &terrorPass{sink: sink}
+}
+
+func (p *terrorPass) Name() string { return "terror" }
+
+func (p *terrorPass) KeyPrefix() string { return keyvalue.ErrorCodeKeyPrefix }
+
+func (p *terrorPass) Visit(node ast.Node, stack stackFunc, helper *AstHelper) {
+	switch n := node.(type) {
+	case *ast.CallExpr:
+		// try to find error code registration
+		p.matchErrorCode(n, stack, helper)
+	}
+}
+
+func (p *terrorPass) matchErrorCode(call *ast.CallExpr, stack stackFunc, helper *AstHelper) {
+	var id *ast.Ident
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		id = fn // New(...) inside terror package
+	case *ast.SelectorExpr:
+		id = fn.Sel // terror.New(...)
+	default:
+		return
+	}
+
+	obj, ok := helper.GetTypeUsed(id).(*types.Func)
+	if !ok || obj.Pkg() == nil || obj.Pkg().Name() != "terror" || obj.Name() != "New" {
+		return
+	}
+	// New(code ErrCode, class ErrClass, scope ErrScope, level ErrLevel, message string, workaround string)
+	if len(call.Args) < 5 {
+		return
+	}
+
+	typeInfo := helper.GetTypeInfo()
+	code := typeInfo.Types[call.Args[0]].Value
+	if code == nil || code.Kind() != constant.Int {
+		return
+	}
+	codeVal, _ := constant.Int64Val(code)
+
+	message := ""
+	if msg := typeInfo.Types[call.Args[4]].Value; msg != nil && msg.Kind() == constant.String {
+		message = constant.StringVal(msg)
+	}
+
+	pos := helper.GetPos(call.Pos())
+	p.sink(&logpattern.ErrorCode{
+		Code:    int32(codeVal),
+		Class:   terrorEnumName(call.Args[1], "Class"),
+		Scope:   terrorEnumName(call.Args[2], "Scope"),
+		Level:   terrorEnumName(call.Args[3], "Level"),
+		Message: message,
+		Name:    registeredName(call, stack),
+		Pos: &logpattern.Position{
+			FilePath:     pos.Filename,
+			LineNumber:   int32(pos.Line),
+			ColumnOffset: int32(pos.Offset),
+		},
+	})
+}
+
+// registeredName returns the variable name that the error is assigned to
+func registeredName(call *ast.CallExpr, stack stackFunc) string {
+	switch p := stack(1).(type) {
+	case *ast.ValueSpec:
+		for i, v := range p.Values {
+			if v == call && i < len(p.Names) {
+				return p.Names[i].Name
+			}
+		}
+	case *ast.AssignStmt:
+		for i, v := range p.Rhs {
+			if v == call && i < len(p.Lhs) {
+				if id, ok := p.Lhs[i].(*ast.Ident); ok {
+					return id.Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// terrorEnumNames are the enum constants whose text in logs isn't derived from their names
+var terrorEnumNames = map[string]string{
+	"ClassDMCtl":   "dmctl",
+	"ClassOpenAPI": "openapi",
+}
+
+// terrorEnumName converts terror enum constants into the text printed in logs,
+// e.g. ClassBinlogOp => binlog-op, ScopeNotSet => not-set, LevelHigh => high
+func terrorEnumName(expr ast.Expr, prefix string) string {
+	var name string
+	switch e := expr.(type) {
+	case *ast.Ident:
+		name = e.Name
+	case *ast.SelectorExpr:
+		name = e.Sel.Name
+	default:
+		return ""
+	}
+
+	if text, ok := terrorEnumNames[name]; ok {
+		return text
+	}
+	name = strings.TrimPrefix(name, prefix)
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// split words on lower->upper and on the last upper of an acronym, e.g. DMWorker => dm-worker
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
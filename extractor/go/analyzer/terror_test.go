@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+
+	logpattern "github.com/IANTHEREAL/logutil/proto"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testTerrorSuite{})
+
+type testTerrorSuite struct {
+}
+
+const testTerrorSource = `package terror
+
+type ErrCode int
+type ErrClass int
+type ErrScope int
+type ErrLevel int
+
+const (
+	ClassDMWorker ErrClass = iota
+	ClassDMCtl
+)
+
+const (
+	ScopeInternal ErrScope = iota
+	ScopeNotSet
+)
+
+const (
+	LevelHigh ErrLevel = iota
+	LevelLow
+)
+
+const (
+	codeWorkerNoStart ErrCode = iota + 11011
+	codeCtlInvalid
+)
+
+type Error struct{}
+
+func New(code ErrCode, class ErrClass, scope ErrScope, level ErrLevel, message string, workaround string) *Error {
+	return &Error{}
+}
+
+var (
+	ErrWorkerNoStart = New(codeWorkerNoStart, ClassDMWorker, ScopeInternal, LevelHigh, "no mysql source is being handled in the worker", "")
+	ErrCtlInvalid    = New(codeCtlInvalid, ClassDMCtl, ScopeNotSet, LevelLow, "invalid "+"command", "")
+)
+
+func newError() *Error {
+	var err *Error
+	err = New(11013, ClassDMWorker, ScopeInternal, LevelHigh, "unnamed", "")
+	return err
+}
+`
+
+func (t *testTerrorSuite) TestErrorCodes(c *C) {
+	file, helper := testTypeCheck(c, "terror", testTerrorSource)
+
+	var codes []string
+	ai := NewCompositeAnalyzer()
+	c.Assert(ai.Register(NewTerrorPass(func(code *logpattern.ErrorCode) {
+		codes = append(codes, fmt.Sprintf("%d [class=%s:scope=%s:level=%s] %s %q at line %d", code.Code, code.Class, code.Scope, code.Level, code.Name, code.Message, code.Pos.LineNumber))
+	})), IsNil)
+	ai.Run(file, helper)
+
+	c.Assert(codes, DeepEquals, []string{
+		`11011 [class=dm-worker:scope=internal:level=high] ErrWorkerNoStart "no mysql source is being handled in the worker" at line 35`,
+		`11012 [class=dmctl:scope=not-set:level=low] ErrCtlInvalid "invalid command" at line 36`,
+		`11013 [class=dm-worker:scope=internal:level=high] err "unnamed" at line 41`,
+	})
+}
+
+func (t *testTerrorSuite) TestTerrorEnumName(c *C) {
+	cases := []struct {
+		expr     ast.Expr
+		prefix   string
+		expected string
+	}{
+		{ast.NewIdent("ClassBinlogOp"), "Class", "binlog-op"},
+		{ast.NewIdent("ClassDMWorker"), "Class", "dm-worker"},
+		{ast.NewIdent("ClassRelayEventLib"), "Class", "relay-event-lib"},
+		{ast.NewIdent("ClassDMCtl"), "Class", "dmctl"},
+		{ast.NewIdent("ClassOpenAPI"), "Class", "openapi"},
+		{&ast.SelectorExpr{X: ast.NewIdent("terror"), Sel: ast.NewIdent("ScopeNotSet")}, "Scope", "not-set"},
+		{ast.NewIdent("ScopeUpstream"), "Scope", "upstream"},
+		{ast.NewIdent("LevelHigh"), "Level", "high"},
+		{&ast.BasicLit{Value: "1"}, "Level", ""},
+	}
+	for _, cs := range cases {
+		c.Assert(terrorEnumName(cs.expr, cs.prefix), Equals, cs.expected, Commentf("%#v", cs.expr))
+	}
+}
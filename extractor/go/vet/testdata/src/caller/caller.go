@@ -0,0 +1,8 @@
+package caller
+
+import "wrapper"
+
+func start() {
+	wrapper.LogError("worker", "failed to start worker") // want `"signature":\["\\"failed to start worker\\""\]`
+	wrapper.LogTwice("failed to start twice")            // want `"level":"error","signature":\["\\"failed to start twice\\""\]`
+}
@@ -0,0 +1,16 @@
+package wrapper
+
+import "github.com/pingcap/log"
+
+func LogError(prefix string, msg string) { // want LogError:"log wrapper\\(level=error, msg=1\\)"
+	log.Error(msg)
+}
+
+// logInfo isn't a wrapper, the info logs are filtered out
+func logInfo(msg string) {
+	log.Info(msg)
+}
+
+func LogTwice(msg string) { // want LogTwice:"log wrapper\\(level=error, msg=0\\)"
+	LogError("twice", msg)
+}
@@ -0,0 +1,220 @@
+// Package vet packages the log extractor as an analysis.Analyzer, so that the log patterns can be
+// extracted by go vet or gopls with the build cache and module handling of go command, e.g.
+//
+//	go vet -a -vettool=$(which logcov-vet) -json ./... 2> patterns.json
+//	logcov import --input patterns.json --output ./repo.logpattern
+//
+// go vet prints the JSON output into stderr, and doesn't print the output of cached packages again,
+// so -a is used to analyze all packages every time.
+package vet
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sync"
+
+	"github.com/IANTHEREAL/logutil/extractor/go/analyzer"
+	logextractor "github.com/IANTHEREAL/logutil/extractor/go/log"
+	"github.com/IANTHEREAL/logutil/pkg/util"
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// LogPatternCategory is the diagnostic category of the extracted log patterns,
+// the message of such diagnostic is the log pattern in JSON format
+const LogPatternCategory = "logpattern"
+
+const doc = `extract log patterns for logcov
+
+The logcov analyzer reports every log of interest as a diagnostic in category "logpattern",
+whose message is the log pattern in JSON format. Functions that print their string parameter
+as log message are recorded as log wrapper facts, so the logs printed through wrappers,
+even the wrappers defined in other packages, are extracted too.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:      "logcov",
+	Doc:       doc,
+	Run:       run,
+	FactTypes: []analysis.Fact{new(WrapperFact)},
+}
+
+var (
+	filterConfig string
+	repo         string
+
+	filterOnce sync.Once
+	filter     *logextractor.Filter
+	filterErr  error
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&filterConfig, "filter", "", "the log filter rule config file, if no config file, default set logLevel = error")
+	Analyzer.Flags.StringVar(&repo, "repo", "", "the repo path recorded in the position of log patterns")
+}
+
+// WrapperFact is the fact of log wrapper function, which prints its string parameter as log message, e.g.
+//
+//	func logError(msg string) { log.Error(msg) }
+type WrapperFact struct {
+	// Level is the log level that the wrapper prints in
+	Level string
+	// MsgIndex is the index of parameter that is printed as log message
+	MsgIndex int
+}
+
+func (*WrapperFact) AFact() {}
+
+func (f *WrapperFact) String() string {
+	return fmt.Sprintf("log wrapper(level=%s, msg=%d)", f.Level, f.MsgIndex)
+}
+
+func loadFilter() (*logextractor.Filter, error) {
+	filterOnce.Do(func() {
+		// set default config, log_level = ["error"]
+		rule := &logpattern_go_proto.LogPatternRule{
+			LogLevel: []string{"error"},
+		}
+		if filterConfig != "" {
+			rule = &logpattern_go_proto.LogPatternRule{}
+			filterErr = util.StrictDecodeFile(filterConfig, rule)
+		}
+		filter = logextractor.NewFilter(rule)
+	})
+	return filter, filterErr
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	filter, err := loadFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	findWrappers(pass, filter)
+
+	files := make(map[string]*token.File, len(pass.Files))
+	for _, file := range pass.Files {
+		tf := pass.Fset.File(file.Pos())
+		files[tf.Name()] = tf
+	}
+
+	ai := analyzer.NewCompositeAnalyzer()
+	err = ai.Register(analyzer.NewLogPassWithWrappers(filter.Filter, wrapperOf(pass), func(pattern *logpattern_go_proto.LogPattern) {
+		if repo != "" {
+			pattern.Pos.PackagePath = &logpattern_go_proto.PackagePath{Repo: repo}
+		}
+		data, err := json.Marshal(pattern)
+		if err != nil {
+			return
+		}
+
+		pos := token.NoPos
+		if tf := files[pattern.Pos.FilePath]; tf != nil {
+			pos = tf.Pos(int(pattern.Pos.ColumnOffset))
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:      pos,
+			Category: LogPatternCategory,
+			Message:  string(data),
+		})
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	helper := analyzer.NewAstHelper(pass.Pkg, pass.Fset, pass.TypesInfo)
+	for _, file := range pass.Files {
+		ai.Run(file, helper)
+	}
+	return nil, nil
+}
+
+// wrapperOf returns the log wrapper lookup of the facts that exported by this and the dependent packages
+func wrapperOf(pass *analysis.Pass) analyzer.WrapperFunc {
+	return func(fn *types.Func) (string, int, bool) {
+		fact := &WrapperFact{}
+		if !pass.ImportObjectFact(fn, fact) {
+			return "", 0, false
+		}
+		return fact.Level, fact.MsgIndex, true
+	}
+}
+
+// findWrappers exports wrapper facts for the functions of package that print their string parameter as log message,
+// the wrappers of wrappers are found until no new wrapper comes out
+func findWrappers(pass *analysis.Pass, filter *logextractor.Filter) {
+	for changed := true; changed; {
+		changed = false
+		for _, file := range pass.Files {
+			for _, decl := range file.Decls {
+				fd, ok := decl.(*ast.FuncDecl)
+				if !ok || fd.Body == nil {
+					continue
+				}
+				fn, ok := pass.TypesInfo.Defs[fd.Name].(*types.Func)
+				if !ok || pass.ImportObjectFact(fn, new(WrapperFact)) {
+					continue
+				}
+
+				if fact := wrapperFact(pass, filter, fd, fn); fact != nil {
+					pass.ExportObjectFact(fn, fact)
+					changed = true
+				}
+			}
+		}
+	}
+}
+
+func wrapperFact(pass *analysis.Pass, filter *logextractor.Filter, fd *ast.FuncDecl, fn *types.Func) *WrapperFact {
+	// string parameter -> parameter index
+	params := make(map[types.Object]int)
+	sig := fn.Type().(*types.Signature)
+	for i := 0; i < sig.Params().Len(); i++ {
+		param := sig.Params().At(i)
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			break
+		}
+		if basic, ok := param.Type().Underlying().(*types.Basic); ok && basic.Kind() == types.String {
+			params[param] = i
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+
+	var fact *WrapperFact
+	ast.Inspect(fd.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || fact != nil {
+			return fact == nil
+		}
+		callee, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || callee.Pkg() == nil {
+			return true
+		}
+
+		// the message is the first argument of log functions
+		level, msgIndex, matched := "", 0, false
+		if level, matched = filter.Filter(callee.Pkg().Name(), callee.Name(), ""); !matched {
+			calleeFact := &WrapperFact{}
+			if !pass.ImportObjectFact(callee, calleeFact) {
+				return true
+			}
+			level, msgIndex = calleeFact.Level, calleeFact.MsgIndex
+		}
+		if msgIndex >= len(call.Args) {
+			return true
+		}
+
+		if id, ok := call.Args[msgIndex].(*ast.Ident); ok {
+			if i, ok := params[pass.TypesInfo.Uses[id]]; ok {
+				fact = &WrapperFact{Level: level, MsgIndex: i}
+			}
+		}
+		return true
+	})
+	return fact
+}
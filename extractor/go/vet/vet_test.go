@@ -0,0 +1,23 @@
+package vet
+
+import (
+	"testing"
+
+	. "github.com/pingcap/check"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestClient(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testVetSuite{})
+
+type testVetSuite struct {
+}
+
+// TestWrapperFacts checks that the log wrappers are exported as facts, and the logs printed
+// through the wrappers of another package are extracted
+func (t *testVetSuite) TestWrapperFacts(c *C) {
+	analysistest.Run(c, analysistest.TestData(), Analyzer, "wrapper", "caller")
+}
@@ -0,0 +1,93 @@
+package regex_extractor
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/IANTHEREAL/logutil/pkg/util"
+)
+
+const (
+	// levelGroup is the regexp group name that captures log level
+	levelGroup = "level"
+	// msgGroup is the regexp group name that captures log message literal
+	msgGroup = "msg"
+)
+
+// Config is the config of regex extractor, e.g.
+//
+//	[[rules]]
+//	files = ["**/*.py"]
+//	pattern = '''(?:logger|logging)\.(?P<level>error|warning|critical)\(\s*(?P<msg>"[^"]*"|'[^']*')'''
+//	placeholders = ['%[-+ #0-9.]*[sdfrx]', '\{[^{}]*\}']
+//	[rules.escapes]
+//	"%%" = "%"
+//	"{{" = "{"
+//	"}}" = "}"
+//	[rules.levels]
+//	warning = "warn"
+//	critical = "fatal"
+type Config struct {
+	Rules []*Rule `toml:"rules"`
+}
+
+// Rule describes how to find logs in the files matched by globs
+type Rule struct {
+	// Files are the globs of files relative to codebase, ** matches any directories,
+	// the glob without slash matches file name, e.g. *.py
+	Files []string `toml:"files"`
+	// Pattern is the regexp to find log, it captures the message literal in group msg,
+	// and the log level in group level
+	Pattern string `toml:"pattern"`
+	// Level is the log level if pattern doesn't capture it
+	Level string `toml:"level"`
+	// Levels maps the captured level to the log level, e.g. warning => warn
+	Levels map[string]string `toml:"levels"`
+	// Placeholders are the regexps of format placeholders in message, they match any text in logs
+	Placeholders []string `toml:"placeholders"`
+	// Escapes maps the escape sequences in message to the text printed in logs, e.g. %% => %,
+	// they take precedence over placeholders
+	Escapes map[string]string `toml:"escapes"`
+
+	pattern      *regexp.Regexp
+	placeholders []*regexp.Regexp
+}
+
+// LoadConfig reads the config file of regex extractor
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	if err := util.StrictDecodeFile(path, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, cfg.compile()
+}
+
+func (cfg *Config) compile() error {
+	for i, rule := range cfg.Rules {
+		if len(rule.Files) == 0 {
+			return fmt.Errorf("rule %d has no files", i)
+		}
+
+		var err error
+		rule.pattern, err = regexp.Compile(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("rule %d has invalid pattern: %v", i, err)
+		}
+		if rule.pattern.SubexpIndex(msgGroup) < 0 {
+			return fmt.Errorf("rule %d pattern has no group %s", i, msgGroup)
+		}
+		if rule.pattern.SubexpIndex(levelGroup) < 0 && rule.Level == "" {
+			return fmt.Errorf("rule %d pattern has no group %s, and no level is set", i, levelGroup)
+		}
+
+		rule.placeholders = rule.placeholders[:0]
+		for _, placeholder := range rule.Placeholders {
+			re, err := regexp.Compile(placeholder)
+			if err != nil {
+				return fmt.Errorf("rule %d has invalid placeholder %s: %v", i, placeholder, err)
+			}
+			rule.placeholders = append(rule.placeholders, re)
+		}
+	}
+	return nil
+}
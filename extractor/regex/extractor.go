@@ -0,0 +1,234 @@
+package regex_extractor
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/IANTHEREAL/logutil/pkg/util"
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+)
+
+// LogPatternSink receives the log patterns found by extractor
+type LogPatternSink func(*logpattern_go_proto.LogPattern)
+
+// Extractor used to find logs in the sources of any language by regexps in config
+type Extractor struct {
+	cfg  *Config
+	rule *logpattern_go_proto.LogPatternRule
+	repo string
+}
+
+// NewExtractor returns an extractor, the log patterns are filtered by rule, and their PackagePath.Repo are set to repo
+func NewExtractor(cfg *Config, rule *logpattern_go_proto.LogPatternRule, repo string) *Extractor {
+	return &Extractor{cfg: cfg, rule: rule, repo: repo}
+}
+
+// Extract walks all files under root that matched by the rules, hidden directories are skipped
+func (e *Extractor) Extract(root string, sink LogPatternSink) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != root && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rules := e.matchRules(filepath.ToSlash(rel))
+		if len(rules) == 0 {
+			return nil
+		}
+
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			e.ExtractFile(rule, path, src, sink)
+		}
+		return nil
+	})
+}
+
+func (e *Extractor) matchRules(path string) []*Rule {
+	var rules []*Rule
+	for _, rule := range e.cfg.Rules {
+		for _, glob := range rule.Files {
+			if matchGlob(glob, path) {
+				rules = append(rules, rule)
+				break
+			}
+		}
+	}
+	return rules
+}
+
+// ExtractFile finds the logs in the source by rule
+func (e *Extractor) ExtractFile(rule *Rule, path string, src []byte, sink LogPatternSink) {
+	msgIndex := rule.pattern.SubexpIndex(msgGroup)
+	levelIndex := rule.pattern.SubexpIndex(levelGroup)
+
+	for _, loc := range rule.pattern.FindAllSubmatchIndex(src, -1) {
+		if loc[2*msgIndex] < 0 {
+			continue
+		}
+
+		level := rule.Level
+		if levelIndex >= 0 && loc[2*levelIndex] >= 0 {
+			level = string(src[loc[2*levelIndex]:loc[2*levelIndex+1]])
+		}
+		if alias, ok := rule.Levels[level]; ok {
+			level = alias
+		}
+		level = strings.ToLower(level)
+
+		signature := rule.signature(string(src[loc[2*msgIndex]:loc[2*msgIndex+1]]))
+		if !util.MatchLogPatternRule(e.rule, level, signature) {
+			continue
+		}
+
+		sink(&logpattern_go_proto.LogPattern{
+			Pos: &logpattern_go_proto.Position{
+				FilePath:     path,
+				LineNumber:   int32(bytes.Count(src[:loc[0]], []byte{'\n'}) + 1),
+				ColumnOffset: int32(loc[0]),
+				PackagePath:  &logpattern_go_proto.PackagePath{Repo: e.repo},
+			},
+			// the enclosing function is unknown
+			Func: &logpattern_go_proto.FuncInfo{
+				Name: "<module>",
+				Pos: &logpattern_go_proto.Position{
+					FilePath:   path,
+					LineNumber: 1,
+				},
+			},
+			Level:     level,
+			Signature: []string{signature},
+		})
+	}
+}
+
+// signature converts the message literal into the signature in go format string style that log matcher uses,
+// the placeholders are replaced by %v, e.g.
+//
+//	'failed to connect %s: {}' => "failed to connect %v: %v"
+func (rule *Rule) signature(msg string) string {
+	msg = unquote(msg)
+
+	// [start, end) of placeholders
+	var spans [][]int
+	for _, placeholder := range rule.placeholders {
+		spans = append(spans, placeholder.FindAllStringIndex(msg, -1)...)
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i][0] < spans[j][0]
+	})
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(msg); i++ {
+		if escape, text, ok := rule.matchEscape(msg[i:]); ok {
+			b.WriteString(strings.ReplaceAll(text, "%", "%%"))
+			i += len(escape) - 1
+		} else if len(spans) > 0 && spans[0][0] == i && spans[0][1] > i {
+			b.WriteString("%v")
+			i = spans[0][1] - 1
+		} else {
+			switch c := msg[i]; c {
+			case '%':
+				b.WriteString("%%")
+			case '\\':
+				// keep escape sequences as they are
+				b.WriteByte(c)
+				if i+1 < len(msg) {
+					i++
+					b.WriteByte(msg[i])
+				}
+			case '"':
+				b.WriteString(`\"`)
+			default:
+				b.WriteByte(c)
+			}
+		}
+
+		// drop the overlapped placeholders
+		for len(spans) > 0 && spans[0][0] <= i {
+			spans = spans[1:]
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// matchEscape returns the longest escape sequence at the beginning of msg
+func (rule *Rule) matchEscape(msg string) (string, string, bool) {
+	var escape, text string
+	for e, t := range rule.Escapes {
+		if len(e) > len(escape) && strings.HasPrefix(msg, e) {
+			escape, text = e, t
+		}
+	}
+	return escape, text, escape != ""
+}
+
+// unquote strips the quotes of string literal, e.g. "abc", 'abc', """abc""", `abc`, f"abc", rb'abc',
+// the message that isn't a string literal is returned as it is
+func unquote(lit string) string {
+	// the string prefix has at most 2 letters, and it's followed by quote
+	prefix := 0
+	for prefix < 2 && prefix < len(lit) && strings.IndexByte("fFrRbBuU", lit[prefix]) >= 0 {
+		prefix++
+	}
+	if prefix > 0 && prefix < len(lit) && strings.IndexByte("\"'`", lit[prefix]) >= 0 {
+		lit = lit[prefix:]
+	}
+	for _, quote := range []string{`"""`, `'''`, `"`, `'`, "`"} {
+		if len(lit) >= 2*len(quote) && strings.HasPrefix(lit, quote) && strings.HasSuffix(lit, quote) {
+			return lit[len(quote) : len(lit)-len(quote)]
+		}
+	}
+	return lit
+}
+
+// matchGlob reports whether the slash separated path matches glob, ** matches zero or more directories,
+// the glob without slash matches the file name
+func matchGlob(glob, path string) bool {
+	if !strings.Contains(glob, "/") {
+		ok, _ := filepath.Match(glob, filepath.Base(path))
+		return ok
+	}
+	return matchSegments(strings.Split(glob, "/"), strings.Split(path, "/"))
+}
+
+func matchSegments(glob, path []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			for i := 0; i <= len(path); i++ {
+				if matchSegments(glob[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(path) == 0 {
+			return false
+		}
+		if ok, _ := filepath.Match(glob[0], path[0]); !ok {
+			return false
+		}
+		glob, path = glob[1:], path[1:]
+	}
+	return len(path) == 0
+}
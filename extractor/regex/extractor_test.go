@@ -0,0 +1,166 @@
+package regex_extractor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	. "github.com/pingcap/check"
+)
+
+func TestClient(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testRegexExtractorSuite{})
+
+type testRegexExtractorSuite struct {
+}
+
+const testRegexConfig = `
+[[rules]]
+files = ["**/*.py"]
+pattern = '''(?:logger|logging)\.(?P<level>error|warning|critical)\(\s*(?P<msg>[fr]?"[^"]*"|'[^']*')'''
+placeholders = ['%[-+ #0-9.]*[sdfrx]', '\{[^{}]*\}']
+[rules.escapes]
+"%%" = "%"
+"{{" = "{"
+"}}" = "}"
+[rules.levels]
+warning = "warn"
+critical = "fatal"
+
+[[rules]]
+files = ["connectors/**/src/*.java"]
+pattern = '''(?i:log)\.(?P<level>error|warn)\(\s*(?P<msg>"(?:[^"\\]|\\.)*")'''
+placeholders = ['\{\}']
+
+[[rules]]
+files = ["**/*.sh"]
+pattern = '''log_(?P<level>error) (?P<msg>[^\n"]+)'''
+`
+
+const testPythonSource = `import logging
+
+logger = logging.getLogger(__name__)
+
+def sync(table):
+    try:
+        do_sync(table)
+    except Exception as e:
+        logger.error("failed to sync %s: %d%% done", table, 50)
+        logging.warning(
+            'retry {0} in {delay}s, "soon" {{ok}}', table)
+    logger.info("done")
+    logger.critical(f"abort {table}")
+`
+
+const testJavaSource = `class Sink {
+    void write() {
+        LOG.error("write to {} failed, offset \"{}\"", topic, offset);
+        log.warn("slow write");
+    }
+}
+`
+
+// the messages of shell scripts are not quoted
+const testShellSource = `#!/bin/bash
+log_error failed to connect
+log_error bad config
+`
+
+func (t *testRegexExtractorSuite) TestExtract(c *C) {
+	tmpdir, err := ioutil.TempDir("", "regex_extractor")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(tmpdir)
+
+	configPath := filepath.Join(tmpdir, "regex.toml")
+	c.Assert(ioutil.WriteFile(configPath, []byte(testRegexConfig), 0644), IsNil)
+	cfg, err := LoadConfig(configPath)
+	c.Assert(err, IsNil)
+
+	root := filepath.Join(tmpdir, "codebase")
+	files := map[string]string{
+		"tools/sync.py":                          testPythonSource,
+		"scripts/start.sh":                       testShellSource,
+		"connectors/kafka/src/Sink.java":         testJavaSource,
+		"connectors/kafka/test/SinkTest.java":    testJavaSource,
+		".git/hooks/hook.py":                     testPythonSource,
+		"connectors/kafka/src/Sink.java.orig.py": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0755), IsNil)
+		c.Assert(ioutil.WriteFile(path, []byte(content), 0644), IsNil)
+	}
+
+	type brief struct {
+		file      string
+		line      int32
+		level     string
+		signature string
+	}
+	var briefs []brief
+	e := NewExtractor(cfg, nil, "github.com/example/stack")
+	err = e.Extract(root, func(lp *logpattern_go_proto.LogPattern) {
+		c.Assert(lp.Pos.PackagePath.Repo, Equals, "github.com/example/stack")
+		rel, err := filepath.Rel(root, lp.Pos.FilePath)
+		c.Assert(err, IsNil)
+		briefs = append(briefs, brief{rel, lp.Pos.LineNumber, lp.Level, lp.Signature[0]})
+	})
+	c.Assert(err, IsNil)
+	c.Assert(briefs, DeepEquals, []brief{
+		{"connectors/kafka/src/Sink.java", 3, "error", `"write to %v failed, offset \"%v\""`},
+		{"connectors/kafka/src/Sink.java", 4, "warn", `"slow write"`},
+		{"scripts/start.sh", 2, "error", `"failed to connect"`},
+		{"scripts/start.sh", 3, "error", `"bad config"`},
+		{"tools/sync.py", 9, "error", `"failed to sync %v: %v%% done"`},
+		{"tools/sync.py", 10, "warn", `"retry %v in %vs, \"soon\" {ok}"`},
+		{"tools/sync.py", 13, "fatal", `"abort %v"`},
+	})
+
+	// filter by log pattern rule
+	briefs = briefs[:0]
+	e = NewExtractor(cfg, &logpattern_go_proto.LogPatternRule{LogLevel: []string{"fatal"}}, "")
+	err = e.Extract(root, func(lp *logpattern_go_proto.LogPattern) {
+		briefs = append(briefs, brief{filepath.Base(lp.Pos.FilePath), lp.Pos.LineNumber, lp.Level, lp.Signature[0]})
+	})
+	c.Assert(err, IsNil)
+	c.Assert(briefs, DeepEquals, []brief{{"sync.py", 13, "fatal", `"abort %v"`}})
+}
+
+func (t *testRegexExtractorSuite) TestUnquote(c *C) {
+	cases := []struct {
+		lit, expected string
+	}{
+		{`"abc"`, "abc"},
+		{`'abc'`, "abc"},
+		{`"""abc"""`, "abc"},
+		{"`abc`", "abc"},
+		{`f"abc {x}"`, "abc {x}"},
+		{`rb'abc'`, "abc"},
+		{`BR"abc"`, "abc"},
+		// not string literals
+		{"failed to connect", "failed to connect"},
+		{"bad config", "bad config"},
+		{"ruby", "ruby"},
+		{`bur"abc"`, `bur"abc"`},
+		{"f", "f"},
+		{"", ""},
+	}
+	for _, cs := range cases {
+		c.Assert(unquote(cs.lit), Equals, cs.expected, Commentf("literal %s", cs.lit))
+	}
+}
+
+func (t *testRegexExtractorSuite) TestMatchGlob(c *C) {
+	c.Assert(matchGlob("*.py", "a/b/c.py"), IsTrue)
+	c.Assert(matchGlob("**/*.py", "c.py"), IsTrue)
+	c.Assert(matchGlob("**/*.py", "a/b/c.py"), IsTrue)
+	c.Assert(matchGlob("a/**/src/*.java", "a/src/X.java"), IsTrue)
+	c.Assert(matchGlob("a/**/src/*.java", "a/b/c/src/X.java"), IsTrue)
+	c.Assert(matchGlob("a/**/src/*.java", "a/b/test/X.java"), IsFalse)
+	c.Assert(matchGlob("a/*.py", "a/b/c.py"), IsFalse)
+}
@@ -0,0 +1,239 @@
+package rust_extractor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IANTHEREAL/logutil/pkg/util"
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+)
+
+// macroLevels maps the log macros of log and slog crates to log level,
+// slog_xxx! are the aliases exported by slog to avoid name conflicts with log crate
+var macroLevels = map[string]string{
+	"error":      "error",
+	"warn":       "warn",
+	"info":       "info",
+	"debug":      "debug",
+	"trace":      "trace",
+	"crit":       "fatal",
+	"slog_error": "error",
+	"slog_warn":  "warn",
+	"slog_info":  "info",
+	"slog_debug": "debug",
+	"slog_trace": "trace",
+	"slog_crit":  "fatal",
+}
+
+// LogPatternSink receives the log patterns found by extractor
+type LogPatternSink func(*logpattern_go_proto.LogPattern)
+
+// Extractor used to find log macro invocations in rust sources, e.g.
+//
+//	error!("failed to connect {}: {:?}", addr, e);
+//	error!(logger, "failed to connect"; "addr" => addr);
+//	error!(?e; "failed to connect"; "addr" => addr);
+type Extractor struct {
+	rule *logpattern_go_proto.LogPatternRule
+	repo string
+}
+
+// NewExtractor returns an extractor, the log patterns are filtered by rule, and their PackagePath.Repo are set to repo
+func NewExtractor(rule *logpattern_go_proto.LogPatternRule, repo string) *Extractor {
+	return &Extractor{rule: rule, repo: repo}
+}
+
+// Extract walks all rust sources under root, the target directories of cargo and hidden directories are skipped
+func (e *Extractor) Extract(root string, sink LogPatternSink) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != root && (info.Name() == "target" || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filepath.Ext(path) != ".rs" {
+			return nil
+		}
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		e.ExtractFile(path, src, sink)
+		return nil
+	})
+}
+
+// fnScope is the body of a function
+type fnScope struct {
+	name  string
+	pos   *logpattern_go_proto.Position
+	depth int
+}
+
+// ExtractFile finds the log macro invocations in the rust source
+func (e *Extractor) ExtractFile(path string, src []byte, sink LogPatternSink) {
+	lex := newLexer(src)
+	var (
+		// the last token
+		prev token
+		// brace depth
+		depth  int
+		scopes []*fnScope
+		// the function whose body has not started
+		pending *fnScope
+	)
+
+	for tok := lex.next(); tok.kind != tokEOF; prev, tok = tok, lex.next() {
+		switch {
+		case tok.kind == tokIdent && prev.kind == tokIdent && prev.text == "fn":
+			pending = &fnScope{name: tok.text, pos: position(path, tok)}
+		case tok.text == ";" && pending != nil && depth == pending.depth:
+			// function declaration without body, e.g. trait methods
+			pending = nil
+		case tok.text == "{":
+			if pending != nil {
+				pending.depth = depth
+				scopes = append(scopes, pending)
+				pending = nil
+			}
+			depth++
+		case tok.text == "}":
+			depth--
+			if len(scopes) > 0 && scopes[len(scopes)-1].depth == depth {
+				scopes = scopes[:len(scopes)-1]
+			}
+		case tok.text == "!" && prev.kind == tokIdent:
+			level, ok := macroLevels[prev.text]
+			if !ok {
+				continue
+			}
+			args := lex.next()
+			if args.text != "(" && args.text != "[" && args.text != "{" {
+				continue
+			}
+			lit, ok := e.formatString(lex, args)
+			if !ok {
+				continue
+			}
+
+			signature := formatToSignature(lit)
+			if !util.MatchLogPatternRule(e.rule, level, signature) {
+				continue
+			}
+
+			// rust log records the line of macro invocation
+			pos := position(path, prev)
+			pos.PackagePath = &logpattern_go_proto.PackagePath{Repo: e.repo}
+			fn := &logpattern_go_proto.FuncInfo{Name: "<module>", Pos: position(path, token{line: 1})}
+			if len(scopes) > 0 {
+				scope := scopes[len(scopes)-1]
+				fn = &logpattern_go_proto.FuncInfo{Name: scope.name, Pos: scope.pos}
+			}
+			sink(&logpattern_go_proto.LogPattern{
+				Pos:       pos,
+				Func:      fn,
+				Level:     level,
+				Signature: []string{signature},
+			})
+		}
+	}
+}
+
+// formatString consumes the macro arguments, and returns the first string literal argument that isn't the target
+func (e *Extractor) formatString(lex *lexer, open token) (string, bool) {
+	closing := map[string]string{"(": ")", "[": "]", "{": "}"}
+	var (
+		stack = []string{closing[open.text]}
+		lit   string
+		found bool
+
+		prev, prev2 token
+	)
+	for tok := lex.next(); tok.kind != tokEOF && len(stack) > 0; prev2, prev, tok = prev, tok, lex.next() {
+		switch tok.text {
+		case "(", "[", "{":
+			stack = append(stack, closing[tok.text])
+			continue
+		case ")", "]", "}":
+			if stack[len(stack)-1] == tok.text {
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+
+		if tok.kind == tokString && len(stack) == 1 && !found && !(prev.text == ":" && prev2.text == "target") {
+			lit, found = tok.text, true
+		}
+	}
+	return lit, found
+}
+
+func position(path string, tok token) *logpattern_go_proto.Position {
+	return &logpattern_go_proto.Position{
+		FilePath:     path,
+		LineNumber:   int32(tok.line),
+		ColumnOffset: int32(tok.offset),
+	}
+}
+
+// formatToSignature converts rust format string into the signature in go format string style that log matcher uses, e.g.
+//
+//	"failed to connect {}: {:?}" => "failed to connect %v: %v"
+//	r#"{"key": {}}"# => "{\"key\": %v}"
+func formatToSignature(lit string) string {
+	// strip byte/raw string prefix
+	lit = strings.TrimPrefix(lit, "b")
+	raw := strings.HasPrefix(lit, "r")
+	if raw {
+		lit = strings.Trim(strings.TrimPrefix(lit, "r"), "#")
+	}
+	if len(lit) >= 2 {
+		lit = lit[1 : len(lit)-1]
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(lit); i++ {
+		c := lit[i]
+		switch {
+		case c == '{' && i+1 < len(lit) && lit[i+1] == '{':
+			b.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(lit) && lit[i+1] == '}':
+			b.WriteByte('}')
+			i++
+		case c == '{':
+			// placeholder, e.g. {}, {:?}, {0}, {name:>8}
+			end := strings.IndexByte(lit[i:], '}')
+			if end < 0 {
+				b.WriteString(lit[i:])
+				i = len(lit)
+				continue
+			}
+			b.WriteString("%v")
+			i += end
+		case c == '%':
+			b.WriteString("%%")
+		case raw && (c == '"' || c == '\\'):
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case !raw && c == '\\' && i+1 < len(lit):
+			// keep escape sequences as they are
+			b.WriteByte(c)
+			b.WriteByte(lit[i+1])
+			i++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package rust_extractor
+
+import (
+	"testing"
+
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	. "github.com/pingcap/check"
+)
+
+func TestClient(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testRustExtractorSuite{})
+
+type testRustExtractorSuite struct {
+}
+
+const testRustSource = `use slog::{error as slog_err, info};
+
+// error!("in comment {}", 1);
+/* nested /* error!("in block comment") */ still comment */
+static MSG: &str = "error!(\"in string\")";
+
+macro_rules! fail {
+    ($e:expr) => { error!("macro failed {}", $e) };
+}
+
+pub trait Handler {
+    fn handle(&self) -> Result<(), String>;
+}
+
+impl<'a> Server<'a> {
+    fn connect(&self, addr: &str) -> Result<(), Error> {
+        let c = '{';
+        if let Err(e) = self.dial(addr) {
+            error!("failed to connect {}: {:?}", addr, e);
+            log::warn!(target: "net", "retry {addr} in {}ms, 100% sure", 10);
+            return Err(e);
+        }
+        info!(self.logger, "connected"; "addr" => addr);
+        Ok(())
+    }
+}
+
+fn main() {
+    let closure = |x: u32| {
+        slog_error!(?x; r#"bad {{"json": {}}}"#; "k" => 1);
+    };
+    error![
+        "multi line {{escaped}}"
+    ];
+    if a != b {}
+}
+`
+
+func (t *testRustExtractorSuite) TestExtractFile(c *C) {
+	e := NewExtractor(nil, "github.com/tikv/tikv")
+
+	var patterns []*logpattern_go_proto.LogPattern
+	e.ExtractFile("/tikv/src/server.rs", []byte(testRustSource), func(lp *logpattern_go_proto.LogPattern) {
+		patterns = append(patterns, lp)
+	})
+
+	type brief struct {
+		line      int32
+		fn        string
+		level     string
+		signature string
+	}
+	var briefs []brief
+	for _, lp := range patterns {
+		c.Assert(lp.Pos.FilePath, Equals, "/tikv/src/server.rs")
+		c.Assert(lp.Pos.PackagePath.Repo, Equals, "github.com/tikv/tikv")
+		briefs = append(briefs, brief{lp.Pos.LineNumber, lp.Func.Name, lp.Level, lp.Signature[0]})
+	}
+	c.Assert(briefs, DeepEquals, []brief{
+		{8, "<module>", "error", `"macro failed %v"`},
+		{19, "connect", "error", `"failed to connect %v: %v"`},
+		{20, "connect", "warn", `"retry %v in %vms, 100%% sure"`},
+		{23, "connect", "info", `"connected"`},
+		{30, "main", "error", `"bad {\"json\": %v}"`},
+		{32, "main", "error", `"multi line {escaped}"`},
+	})
+	c.Assert(patterns[1].Func.Pos.LineNumber, Equals, int32(16))
+}
+
+func (t *testRustExtractorSuite) TestExtractFileWithRule(c *C) {
+	e := NewExtractor(&logpattern_go_proto.LogPatternRule{LogLevel: []string{"warn"}}, "")
+
+	var patterns []*logpattern_go_proto.LogPattern
+	e.ExtractFile("server.rs", []byte(testRustSource), func(lp *logpattern_go_proto.LogPattern) {
+		patterns = append(patterns, lp)
+	})
+	c.Assert(patterns, HasLen, 1)
+	c.Assert(patterns[0].Level, Equals, "warn")
+}
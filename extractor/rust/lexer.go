@@ -0,0 +1,236 @@
+package rust_extractor
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	// string literal, including raw string and byte string, e.g. "abc", r#"abc"#, b"abc"
+	tokString
+	// char literal or lifetime, e.g. 'a', 'static
+	tokChar
+	tokNumber
+	tokPunct
+)
+
+// token is a lexical token of rust source
+type token struct {
+	kind tokenKind
+	// text is the raw text of token in the source
+	text string
+	// offset is the byte offset of token in the source
+	offset int
+	line   int
+}
+
+// lexer splits rust source into tokens, it only recognizes the tokens that
+// extractor cares about, all the other characters are returned as single punctuation
+type lexer struct {
+	src    []byte
+	offset int
+	line   int
+}
+
+func newLexer(src []byte) *lexer {
+	return &lexer{src: src, line: 1}
+}
+
+func (l *lexer) peek(i int) byte {
+	if l.offset+i < len(l.src) {
+		return l.src[l.offset+i]
+	}
+	return 0
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.offset < len(l.src); i++ {
+		if l.src[l.offset] == '\n' {
+			l.line++
+		}
+		l.offset++
+	}
+}
+
+// next returns the next token, comments and whitespaces are skipped
+func (l *lexer) next() token {
+	l.skipSpaceAndComment()
+	if l.offset >= len(l.src) {
+		return token{kind: tokEOF, offset: l.offset, line: l.line}
+	}
+
+	start, line := l.offset, l.line
+	kind := l.scan()
+	return token{kind: kind, text: string(l.src[start:l.offset]), offset: start, line: line}
+}
+
+func (l *lexer) skipSpaceAndComment() {
+	for l.offset < len(l.src) {
+		switch c := l.peek(0); {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			l.advance(1)
+		case c == '/' && l.peek(1) == '/':
+			for l.offset < len(l.src) && l.peek(0) != '\n' {
+				l.advance(1)
+			}
+		case c == '/' && l.peek(1) == '*':
+			// block comments can be nested in rust
+			l.advance(2)
+			for depth := 1; depth > 0 && l.offset < len(l.src); {
+				if l.peek(0) == '/' && l.peek(1) == '*' {
+					depth++
+					l.advance(2)
+				} else if l.peek(0) == '*' && l.peek(1) == '/' {
+					depth--
+					l.advance(2)
+				} else {
+					l.advance(1)
+				}
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) scan() tokenKind {
+	c := l.peek(0)
+	switch {
+	case c == '"':
+		l.scanString()
+		return tokString
+	case (c == 'r' && (l.peek(1) == '"' || l.peek(1) == '#')) || (c == 'b' && l.peek(1) == 'r' && (l.peek(2) == '"' || l.peek(2) == '#')):
+		if c == 'b' {
+			l.advance(1)
+		}
+		if l.scanRawString() {
+			return tokString
+		}
+		return tokIdent
+	case c == 'b' && l.peek(1) == '"':
+		l.advance(1)
+		l.scanString()
+		return tokString
+	case c == 'b' && l.peek(1) == '\'':
+		l.advance(1)
+		l.scanChar()
+		return tokChar
+	case c == '\'':
+		l.scanChar()
+		return tokChar
+	case c >= '0' && c <= '9':
+		for isIdentByte(l.peek(0)) || (l.peek(0) == '.' && l.peek(1) >= '0' && l.peek(1) <= '9') {
+			l.advance(1)
+		}
+		return tokNumber
+	case isIdentStart(l.src[l.offset:]):
+		l.scanIdent()
+		return tokIdent
+	case c == ':' && l.peek(1) == ':':
+		l.advance(2)
+		return tokPunct
+	case c == '=' && l.peek(1) == '>':
+		l.advance(2)
+		return tokPunct
+	default:
+		_, size := utf8.DecodeRune(l.src[l.offset:])
+		l.advance(size)
+		return tokPunct
+	}
+}
+
+func (l *lexer) scanString() {
+	l.advance(1)
+	for l.offset < len(l.src) {
+		switch l.peek(0) {
+		case '\\':
+			l.advance(2)
+		case '"':
+			l.advance(1)
+			return
+		default:
+			l.advance(1)
+		}
+	}
+}
+
+// scanRawString scans raw string like r#"abc"#, it returns false if it's an identifier starts with r
+func (l *lexer) scanRawString() bool {
+	hashes := 0
+	for l.peek(1+hashes) == '#' {
+		hashes++
+	}
+	if l.peek(1+hashes) != '"' {
+		// raw identifier like r#type, or an identifier
+		l.advance(1 + hashes)
+		l.scanIdent()
+		return false
+	}
+
+	l.advance(2 + hashes)
+	for l.offset < len(l.src) {
+		if l.peek(0) == '"' {
+			closed := true
+			for i := 1; i <= hashes; i++ {
+				if l.peek(i) != '#' {
+					closed = false
+					break
+				}
+			}
+			if closed {
+				l.advance(1 + hashes)
+				return true
+			}
+		}
+		l.advance(1)
+	}
+	return true
+}
+
+// scanChar scans char literal like 'a', '\n', or lifetime like 'static
+func (l *lexer) scanChar() {
+	l.advance(1)
+	if l.peek(0) == '\\' {
+		for l.offset < len(l.src) && l.peek(0) != '\'' {
+			if l.peek(0) == '\\' {
+				l.advance(1)
+			}
+			l.advance(1)
+		}
+		l.advance(1)
+		return
+	}
+
+	_, size := utf8.DecodeRune(l.src[l.offset:])
+	if l.peek(size) == '\'' {
+		l.advance(size + 1)
+		return
+	}
+	// lifetime
+	l.scanIdent()
+}
+
+func (l *lexer) scanIdent() {
+	for l.offset < len(l.src) && isIdentContinue(l.src[l.offset:]) {
+		_, size := utf8.DecodeRune(l.src[l.offset:])
+		l.advance(size)
+	}
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isIdentStart(src []byte) bool {
+	r, _ := utf8.DecodeRune(src)
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentContinue(src []byte) bool {
+	r, _ := utf8.DecodeRune(src)
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	"github.com/IANTHEREAL/logutil/cmd"
 	"github.com/spf13/cobra"
 )
@@ -10,6 +12,8 @@ func main() {
 		Use:   "logcov",
 		Short: "logcov is a tool that computes the coverage of exception error handling by analyzing the testing log",
 	}
-	rootCmd.AddCommand(cmd.NewExtractCmd(), cmd.NewScanCmd(), cmd.NewAnalyzeCmd())
-	rootCmd.Execute()
+	rootCmd.AddCommand(cmd.NewExtractCmd(), cmd.NewScanCmd(), cmd.NewAnalyzeCmd(), cmd.NewImportCmd(), cmd.NewLintCmd(), cmd.NewAmbiguityCmd(), cmd.NewDupLogCmd(), cmd.NewMergeCmd())
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
 }
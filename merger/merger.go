@@ -0,0 +1,321 @@
+package log_merger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/IANTHEREAL/logutil/pkg/util"
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+)
+
+// codeKeyPrefixes are the key prefixes of the data extracted from code, they are copied from the first log pattern set
+var codeKeyPrefixes = []string{
+	keyvalue.LogPatternKeyPrefix,
+	keyvalue.FunctionKeyPrefix,
+	keyvalue.LogPatternRuleKeyPrefix,
+	keyvalue.ErrorCodeKeyPrefix,
+	keyvalue.SilentErrorPathKeyPrefix,
+}
+
+// Merger merges the coverage of log pattern sets that are extracted from the same code,
+// e.g. the log pattern sets of parallel CI shards that scan their own logs
+type Merger struct {
+	// base is the first log pattern set, the others are compared with it
+	base      string
+	baseStore *keyvalue.Store
+	// root is the common directory of code files of the first set, the code positions of other sets are rebased on it
+	root     string
+	patterns map[string]*logpattern_go_proto.LogPattern
+	rule     []byte
+
+	coverage map[string]*logpattern_go_proto.Coverage
+	errCodes map[int32]*logpattern_go_proto.ErrorCodeCoverage
+	errPaths map[string]*logpattern_go_proto.ErrorPathCoverage
+	unknows  map[string]*logpattern_go_proto.UnknowLogPattern
+
+	conflicts []string
+}
+
+func NewMerger() *Merger {
+	return &Merger{
+		coverage: make(map[string]*logpattern_go_proto.Coverage),
+		errCodes: make(map[int32]*logpattern_go_proto.ErrorCodeCoverage),
+		errPaths: make(map[string]*logpattern_go_proto.ErrorPathCoverage),
+		unknows:  make(map[string]*logpattern_go_proto.UnknowLogPattern),
+	}
+}
+
+// Add adds the coverage of log pattern set, the log files are attributed to the set by prefixing its name,
+// e.g. shard-1:/tmp/dm-worker.log. The log patterns and rule of set are compared with the first set,
+// and the differences are reported by Conflicts. The set may be extracted from the code in another directory,
+// the file paths relative to the common directory of code files are compared
+func (m *Merger) Add(name string, store *keyvalue.Store) error {
+	ctx := context.Background()
+	var all []*logpattern_go_proto.LogPattern
+	err := store.ScanLogPattern(ctx, func(_, value []byte) error {
+		pattern := &logpattern_go_proto.LogPattern{}
+		if err := pattern.Unmarshal(value); err != nil {
+			return err
+		}
+		all = append(all, pattern)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("read log patterns of %s failed %v", name, err)
+	}
+	root, err := codeRoot(store, all)
+	if err != nil {
+		return fmt.Errorf("read functions of %s failed %v", name, err)
+	}
+	patterns := make(map[string]*logpattern_go_proto.LogPattern, len(all))
+	for _, pattern := range all {
+		patterns[patternIdentity(pattern, root)] = pattern
+	}
+	rules, err := scanRaw(store.ScanLogPatternRule)
+	if err != nil {
+		return fmt.Errorf("read log pattern rule of %s failed %v", name, err)
+	}
+	var rule []byte
+	for _, value := range rules {
+		rule = value
+	}
+
+	if m.baseStore == nil {
+		m.base, m.baseStore, m.root, m.patterns, m.rule = name, store, root, patterns, rule
+	} else {
+		m.compare(name, patterns, rule)
+	}
+
+	err = store.ScanLogCoverage(ctx, func(_, value []byte) error {
+		cov := &logpattern_go_proto.Coverage{}
+		if err := cov.Unmarshal(value); err != nil {
+			return err
+		}
+
+		pos := m.rebase(cov.Pos, root)
+		id := util.PosToStr(pos)
+		merged := m.coverage[id]
+		if merged == nil {
+			merged = &logpattern_go_proto.Coverage{Pos: pos, CovCountByLog: make(map[string]int64)}
+			m.coverage[id] = merged
+		}
+		// the samples are merged by the counts of both, so they are still uniform samples of all logs
+		size := len(merged.Samples)
+		if len(cov.Samples) > size {
+			size = len(cov.Samples)
+		}
+		merged.Samples = util.MergeSamples(merged.Samples, merged.CovCount, cov.Samples, cov.CovCount, size)
+		if cov.FirstSeen != 0 && (merged.FirstSeen == 0 || cov.FirstSeen < merged.FirstSeen) {
+			merged.FirstSeen = cov.FirstSeen
+		}
+		if cov.LastSeen > merged.LastSeen {
+			merged.LastSeen = cov.LastSeen
+		}
+		merged.CovCount += cov.CovCount
+		merged.AmbiguousCount += cov.AmbiguousCount
+		attribute(merged.CovCountByLog, name, cov.CovCountByLog)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("read log coverage of %s failed %v", name, err)
+	}
+
+	err = store.ScanErrorCodeCoverage(ctx, func(_, value []byte) error {
+		cov := &logpattern_go_proto.ErrorCodeCoverage{}
+		if err := cov.Unmarshal(value); err != nil {
+			return err
+		}
+
+		merged := m.errCodes[cov.Code]
+		if merged == nil {
+			merged = &logpattern_go_proto.ErrorCodeCoverage{Code: cov.Code, CovCountByLog: make(map[string]int64)}
+			m.errCodes[cov.Code] = merged
+		}
+		merged.CovCount += cov.CovCount
+		attribute(merged.CovCountByLog, name, cov.CovCountByLog)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("read error code coverage of %s failed %v", name, err)
+	}
+
+	err = store.ScanErrorPathCoverage(ctx, func(_, value []byte) error {
+		cov := &logpattern_go_proto.ErrorPathCoverage{}
+		if err := cov.Unmarshal(value); err != nil {
+			return err
+		}
+
+		pos := m.rebase(cov.Pos, root)
+		id := util.PosToStr(pos)
+		merged := m.errPaths[id]
+		if merged == nil {
+			merged = &logpattern_go_proto.ErrorPathCoverage{Pos: pos, CovCountByLog: make(map[string]int64)}
+			m.errPaths[id] = merged
+		}
+		merged.CovCount += cov.CovCount
+		attribute(merged.CovCountByLog, name, cov.CovCountByLog)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("read error path coverage of %s failed %v", name, err)
+	}
+
+	err = store.ScanUnknowLog(ctx, func(_, value []byte) error {
+		unknow := &logpattern_go_proto.UnknowLogPattern{}
+		if err := unknow.Unmarshal(value); err != nil {
+			return err
+		}
+
+		// the position of unknow log is the raw position in log, e.g. server.go:123, and the logs are keyed
+		// by level and position, or by level and message if the position is unknown, like scanning
+		id := util.UnknowLogToStr(unknow)
+		merged := m.unknows[id]
+		if merged == nil {
+			merged = &logpattern_go_proto.UnknowLogPattern{Pos: unknow.Pos, Level: unknow.Level, Msg: unknow.Msg, CovCountByLog: make(map[string]int64)}
+			m.unknows[id] = merged
+		}
+		merged.CovCount += unknow.CovCount
+		attribute(merged.CovCountByLog, name, unknow.CovCountByLog)
+		merged.Samples = util.MergeUnknowLogSamples(merged.Samples, unknow.Samples...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("read unknow logs of %s failed %v", name, err)
+	}
+	return nil
+}
+
+// compare records the differences of log patterns and rule between the log pattern set and the first set
+func (m *Merger) compare(name string, patterns map[string]*logpattern_go_proto.LogPattern, rule []byte) {
+	if !bytes.Equal(rule, m.rule) {
+		m.conflicts = append(m.conflicts, fmt.Sprintf("log pattern rule of %s differs from %s", name, m.base))
+	}
+
+	var conflicts []string
+	for id, pattern := range m.patterns {
+		if _, ok := patterns[id]; !ok {
+			conflicts = append(conflicts, fmt.Sprintf("log pattern %s of %s is missing in %s", describePattern(pattern), m.base, name))
+		}
+	}
+	for id, pattern := range patterns {
+		if _, ok := m.patterns[id]; !ok {
+			conflicts = append(conflicts, fmt.Sprintf("log pattern %s of %s is missing in %s", describePattern(pattern), name, m.base))
+		}
+	}
+	sort.Strings(conflicts)
+	m.conflicts = append(m.conflicts, conflicts...)
+}
+
+// Conflicts returns the differences between the log pattern sets, the sets are not extracted from the same code
+// if there is any conflict
+func (m *Merger) Conflicts() []string {
+	return m.conflicts
+}
+
+// Write writes the log patterns extracted from code and the merged coverage into output
+func (m *Merger) Write(output *keyvalue.Store) error {
+	if m.baseStore == nil {
+		return fmt.Errorf("no log pattern set to merge")
+	}
+	if len(m.conflicts) > 0 {
+		return fmt.Errorf("%d conflicts between log pattern sets", len(m.conflicts))
+	}
+
+	ctx := context.Background()
+	batch := output.Batch()
+	if err := m.baseStore.CopyTo(ctx, batch, codeKeyPrefixes...); err != nil {
+		return err
+	}
+	for _, cov := range m.coverage {
+		if err := batch.WriteLogCoverage(ctx, cov); err != nil {
+			return err
+		}
+	}
+	for _, cov := range m.errCodes {
+		if err := batch.WriteErrorCodeCoverage(ctx, cov); err != nil {
+			return err
+		}
+	}
+	for _, cov := range m.errPaths {
+		if err := batch.WriteErrorPathCoverage(ctx, cov); err != nil {
+			return err
+		}
+	}
+	for _, unknow := range m.unknows {
+		if err := batch.WriteUnknowLog(ctx, unknow); err != nil {
+			return err
+		}
+	}
+	return batch.Commit(ctx)
+}
+
+// attribute adds the counts of log files into merged, the log files are prefixed by the name of log pattern set
+func attribute(merged map[string]int64, name string, counts map[string]int64) {
+	for logPath, count := range counts {
+		merged[name+":"+logPath] += count
+	}
+}
+
+// rebase returns the code position whose file path is moved from the root of log pattern set to the root of first set
+func (m *Merger) rebase(pos *logpattern_go_proto.Position, root string) *logpattern_go_proto.Position {
+	if pos == nil || root == m.root || !strings.HasPrefix(pos.FilePath, root) {
+		return pos
+	}
+	rebased := *pos
+	rebased.FilePath = m.root + strings.TrimPrefix(pos.FilePath, root)
+	return &rebased
+}
+
+// patternIdentity identifies the log pattern by its file path relative to root, line, level and signature
+func patternIdentity(pattern *logpattern_go_proto.LogPattern, root string) string {
+	pos := pattern.GetPos()
+	return fmt.Sprintf("%s:%d:%s:%q", strings.TrimPrefix(pos.GetFilePath(), root), pos.GetLineNumber(), pattern.Level, pattern.Signature)
+}
+
+// codeRoot returns the common directory of the code files that log patterns and functions are extracted from,
+// e.g. /ci/shard-1/ticdc/
+func codeRoot(store *keyvalue.Store, patterns []*logpattern_go_proto.LogPattern) (string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		files = append(files, pattern.GetPos().GetFilePath())
+	}
+	err := store.ScanFunction(context.Background(), func(_, value []byte) error {
+		fn := &logpattern_go_proto.FuncInfo{}
+		if err := fn.Unmarshal(value); err != nil {
+			return err
+		}
+		files = append(files, fn.GetPos().GetFilePath())
+		return nil
+	})
+	if err != nil || len(files) == 0 {
+		return "", err
+	}
+
+	root := files[0][:strings.LastIndex(files[0], "/")+1]
+	for _, file := range files[1:] {
+		for !strings.HasPrefix(file, root) {
+			root = root[:strings.LastIndex(strings.TrimSuffix(root, "/"), "/")+1]
+		}
+	}
+	return root, nil
+}
+
+func scanRaw(scan func(context.Context, func(key, value []byte) error) error) (map[string][]byte, error) {
+	values := make(map[string][]byte)
+	err := scan(context.Background(), func(key, value []byte) error {
+		values[string(key)] = append([]byte{}, value...)
+		return nil
+	})
+	return values, err
+}
+
+func describePattern(pattern *logpattern_go_proto.LogPattern) string {
+	if pattern.Pos == nil {
+		return "<invalid>"
+	}
+	return fmt.Sprintf("%s:%d", pattern.Pos.FilePath, pattern.Pos.LineNumber)
+}
@@ -0,0 +1,201 @@
+package log_merger
+
+import (
+	"context"
+	"testing"
+
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+	"github.com/IANTHEREAL/logutil/storage/leveldb"
+	. "github.com/pingcap/check"
+)
+
+func TestClient(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testMergerSuite{})
+
+type testMergerSuite struct {
+}
+
+func testPos(line int32) *logpattern_go_proto.Position {
+	return &logpattern_go_proto.Position{
+		PackagePath: &logpattern_go_proto.PackagePath{Repo: "github.com/pingcap/ticdc/dm"},
+		FilePath:    "dm/worker/server.go",
+		LineNumber:  line,
+	}
+}
+
+func testShard(c *C, lines []int32, coverage ...*logpattern_go_proto.Coverage) *keyvalue.Store {
+	db, err := leveldb.Open(c.MkDir(), nil)
+	c.Assert(err, IsNil)
+	store := keyvalue.NewLogPatternStore(db)
+
+	ctx := context.Background()
+	c.Assert(store.WriteLogPatternRule(ctx, &logpattern_go_proto.LogPatternRule{LogLevel: []string{"error"}}), IsNil)
+	for _, line := range lines {
+		c.Assert(store.WriteLogPattern(ctx, &logpattern_go_proto.LogPattern{Pos: testPos(line), Level: "error"}), IsNil)
+	}
+	for _, cov := range coverage {
+		c.Assert(store.WriteLogCoverage(ctx, cov), IsNil)
+	}
+	c.Assert(store.WriteErrorCodeCoverage(ctx, &logpattern_go_proto.ErrorCodeCoverage{
+		Code: 11011, CovCount: 1, CovCountByLog: map[string]int64{"dm-worker.log": 1},
+	}), IsNil)
+	c.Assert(store.WriteUnknowLog(ctx, &logpattern_go_proto.UnknowLogPattern{
+		Pos: &logpattern_go_proto.Position{FilePath: "main.go:12"}, Level: "error", CovCount: 1,
+		CovCountByLog: map[string]int64{"dm-worker.log": 1}, Samples: []string{`"start failed"`},
+	}), IsNil)
+	return store
+}
+
+func (t *testMergerSuite) TestMerge(c *C) {
+	m := NewMerger()
+	c.Assert(m.Add("shard1", testShard(c, []int32{10, 20}, &logpattern_go_proto.Coverage{
+		Pos: testPos(10), CovCount: 2, CovCountByLog: map[string]int64{"dm-worker.log": 2},
+		Samples: []string{"a1", "a2"}, FirstSeen: 100, LastSeen: 200,
+	})), IsNil)
+	c.Assert(m.Add("shard2", testShard(c, []int32{10, 20}, &logpattern_go_proto.Coverage{
+		Pos: testPos(10), CovCount: 3, AmbiguousCount: 1, CovCountByLog: map[string]int64{"dm-worker.log": 3},
+		Samples: []string{"b1", "b2"}, FirstSeen: 50, LastSeen: 150,
+	}, &logpattern_go_proto.Coverage{
+		Pos: testPos(20), CovCount: 1, CovCountByLog: map[string]int64{"dm-master.log": 1},
+	})), IsNil)
+	c.Assert(m.Conflicts(), HasLen, 0)
+
+	db, err := leveldb.Open(c.MkDir(), nil)
+	c.Assert(err, IsNil)
+	output := keyvalue.NewLogPatternStore(db)
+	c.Assert(m.Write(output), IsNil)
+
+	ctx := context.Background()
+	cov, err := output.GetLogCoverage(ctx, testPos(10))
+	c.Assert(err, IsNil)
+	c.Assert(cov.CovCount, Equals, int64(5))
+	c.Assert(cov.AmbiguousCount, Equals, int64(1))
+	c.Assert(cov.CovCountByLog, DeepEquals, map[string]int64{"shard1:dm-worker.log": 2, "shard2:dm-worker.log": 3})
+	c.Assert(cov.Samples, HasLen, 2)
+	c.Assert(cov.FirstSeen, Equals, int64(50))
+	c.Assert(cov.LastSeen, Equals, int64(200))
+	cov, err = output.GetLogCoverage(ctx, testPos(20))
+	c.Assert(err, IsNil)
+	c.Assert(cov.CovCountByLog, DeepEquals, map[string]int64{"shard2:dm-master.log": 1})
+	code, err := output.GetErrorCodeCoverage(ctx, 11011)
+	c.Assert(err, IsNil)
+	c.Assert(code.CovCount, Equals, int64(2))
+	unknow, err := output.GetUnknowLog(ctx, &logpattern_go_proto.UnknowLogPattern{Pos: &logpattern_go_proto.Position{FilePath: "main.go:12"}, Level: "error"})
+	c.Assert(err, IsNil)
+	c.Assert(unknow.CovCount, Equals, int64(2))
+	c.Assert(unknow.CovCountByLog, DeepEquals, map[string]int64{"shard1:dm-worker.log": 1, "shard2:dm-worker.log": 1})
+	c.Assert(unknow.Samples, DeepEquals, []string{`"start failed"`})
+
+	patterns := 0
+	c.Assert(output.ScanLogPattern(ctx, func(_, _ []byte) error {
+		patterns++
+		return nil
+	}), IsNil)
+	c.Assert(patterns, Equals, 2)
+}
+
+func (t *testMergerSuite) TestMergeUnknowLogs(c *C) {
+	ctx := context.Background()
+	shard := func(unknows ...*logpattern_go_proto.UnknowLogPattern) *keyvalue.Store {
+		store := testShard(c, []int32{10})
+		for _, unknow := range unknows {
+			unknow.CovCount, unknow.CovCountByLog = 1, map[string]int64{"dm-worker.log": 1}
+			c.Assert(store.WriteUnknowLog(ctx, unknow), IsNil)
+		}
+		return store
+	}
+	noPos := &logpattern_go_proto.Position{}
+	m := NewMerger()
+	c.Assert(m.Add("shard1", shard(
+		&logpattern_go_proto.UnknowLogPattern{Pos: &logpattern_go_proto.Position{FilePath: "main.go:12"}, Level: "warn", Samples: []string{`"a"`, `"b"`}},
+		&logpattern_go_proto.UnknowLogPattern{Pos: noPos, Level: "info", Msg: `"started"`, Samples: []string{`"started"`}},
+	)), IsNil)
+	c.Assert(m.Add("shard2", shard(
+		&logpattern_go_proto.UnknowLogPattern{Pos: &logpattern_go_proto.Position{FilePath: "main.go:12"}, Level: "warn", Samples: []string{`"b"`, `"c"`, `"d"`}},
+		&logpattern_go_proto.UnknowLogPattern{Pos: noPos, Level: "info", Msg: `"stopped"`, Samples: []string{`"stopped"`}},
+	)), IsNil)
+
+	db, err := leveldb.Open(c.MkDir(), nil)
+	c.Assert(err, IsNil)
+	output := keyvalue.NewLogPatternStore(db)
+	c.Assert(m.Write(output), IsNil)
+
+	// the logs at the same position of different levels are not merged, and the samples are capped
+	unknow, err := output.GetUnknowLog(ctx, &logpattern_go_proto.UnknowLogPattern{Pos: &logpattern_go_proto.Position{FilePath: "main.go:12"}, Level: "warn"})
+	c.Assert(err, IsNil)
+	c.Assert(unknow.CovCount, Equals, int64(2))
+	c.Assert(unknow.Samples, DeepEquals, []string{`"a"`, `"b"`, `"c"`})
+	unknow, err = output.GetUnknowLog(ctx, &logpattern_go_proto.UnknowLogPattern{Pos: &logpattern_go_proto.Position{FilePath: "main.go:12"}, Level: "error"})
+	c.Assert(err, IsNil)
+	c.Assert(unknow.CovCount, Equals, int64(2))
+
+	// the logs without position are merged by message
+	for _, msg := range []string{`"started"`, `"stopped"`} {
+		unknow, err = output.GetUnknowLog(ctx, &logpattern_go_proto.UnknowLogPattern{Pos: noPos, Level: "info", Msg: msg})
+		c.Assert(err, IsNil)
+		c.Assert(unknow.CovCount, Equals, int64(1))
+	}
+}
+
+func (t *testMergerSuite) TestConflicts(c *C) {
+	m := NewMerger()
+	c.Assert(m.Add("shard1", testShard(c, []int32{10, 20})), IsNil)
+	c.Assert(m.Add("shard2", testShard(c, []int32{10, 30})), IsNil)
+	c.Assert(m.Conflicts(), DeepEquals, []string{
+		"log pattern dm/worker/server.go:20 of shard1 is missing in shard2",
+		"log pattern dm/worker/server.go:30 of shard2 is missing in shard1",
+	})
+
+	db, err := leveldb.Open(c.MkDir(), nil)
+	c.Assert(err, IsNil)
+	c.Assert(m.Write(keyvalue.NewLogPatternStore(db)), ErrorMatches, "2 conflicts between log pattern sets")
+}
+
+func (t *testMergerSuite) TestMergeCheckouts(c *C) {
+	ctx := context.Background()
+	pos := func(root string, line int32) *logpattern_go_proto.Position {
+		return &logpattern_go_proto.Position{
+			PackagePath: &logpattern_go_proto.PackagePath{Repo: "github.com/pingcap/ticdc/dm"},
+			FilePath:    root + "dm/worker/server.go",
+			LineNumber:  line,
+		}
+	}
+	shard := func(root, signature string) *keyvalue.Store {
+		store := testShard(c, nil)
+		c.Assert(store.WriteLogPattern(ctx, &logpattern_go_proto.LogPattern{Pos: pos(root, 10), Level: "error", Signature: []string{signature}}), IsNil)
+		c.Assert(store.WriteFunction(ctx, &logpattern_go_proto.FuncInfo{Name: "Start", Pos: &logpattern_go_proto.Position{FilePath: root + "dm/master/server.go", LineNumber: 5}}), IsNil)
+		c.Assert(store.WriteLogCoverage(ctx, &logpattern_go_proto.Coverage{Pos: pos(root, 10), CovCount: 1, CovCountByLog: map[string]int64{"dm-worker.log": 1}}), IsNil)
+		c.Assert(store.WriteErrorPathCoverage(ctx, &logpattern_go_proto.ErrorPathCoverage{Pos: pos(root, 5), CovCount: 1, CovCountByLog: map[string]int64{"dm-worker.log": 1}}), IsNil)
+		return store
+	}
+
+	// the log pattern sets extracted from the same code in different directories are merged on the first one
+	m := NewMerger()
+	c.Assert(m.Add("shard1", shard("/ci/shard1/ticdc/", "start %s")), IsNil)
+	c.Assert(m.Add("shard2", shard("/ci/shard2/ticdc/", "start %s")), IsNil)
+	c.Assert(m.Conflicts(), HasLen, 0)
+
+	db, err := leveldb.Open(c.MkDir(), nil)
+	c.Assert(err, IsNil)
+	output := keyvalue.NewLogPatternStore(db)
+	c.Assert(m.Write(output), IsNil)
+	cov, err := output.GetLogCoverage(ctx, pos("/ci/shard1/ticdc/", 10))
+	c.Assert(err, IsNil)
+	c.Assert(cov.CovCountByLog, DeepEquals, map[string]int64{"shard1:dm-worker.log": 1, "shard2:dm-worker.log": 1})
+	path, err := output.GetErrorPathCoverage(ctx, pos("/ci/shard1/ticdc/", 5))
+	c.Assert(err, IsNil)
+	c.Assert(path.CovCount, Equals, int64(2))
+
+	// the log patterns of different signatures conflict
+	m = NewMerger()
+	c.Assert(m.Add("shard1", shard("/ci/shard1/ticdc/", "start %s")), IsNil)
+	c.Assert(m.Add("shard2", shard("/ci/shard2/ticdc/", "stop %s")), IsNil)
+	c.Assert(m.Conflicts(), DeepEquals, []string{
+		"log pattern /ci/shard1/ticdc/dm/worker/server.go:10 of shard1 is missing in shard2",
+		"log pattern /ci/shard2/ticdc/dm/worker/server.go:10 of shard2 is missing in shard1",
+	})
+}
@@ -0,0 +1,76 @@
+package util
+
+import "math/rand"
+
+// Sample adds the line into the uniform sample of at most size lines, count is the number of lines seen including it
+func Sample(samples []string, count int64, line string, size int) []string {
+	if size <= 0 {
+		return samples
+	}
+	if len(samples) < size {
+		return append(samples, line)
+	}
+
+	if i := rand.Int63n(count); i < int64(size) {
+		samples[i] = line
+	}
+	return samples
+}
+
+// MergeSamples merges the uniform samples of a and b lines into an uniform sample of at most size lines of both
+func MergeSamples(a []string, na int64, b []string, nb int64, size int) []string {
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	// the samples contain all the lines if there are fewer lines than samples
+	if na < int64(len(a)) {
+		na = int64(len(a))
+	}
+	if nb < int64(len(b)) {
+		nb = int64(len(b))
+	}
+
+	merged := make([]string, 0, size)
+	for len(merged) < size && len(a)+len(b) > 0 {
+		// the next line is from a with the probability of the lines of a that are not picked yet
+		fromA := len(b) == 0 || (len(a) > 0 && rand.Int63n(na+nb) < na)
+		if fromA {
+			a, merged = pick(a, merged)
+			na--
+		} else {
+			b, merged = pick(b, merged)
+			nb--
+		}
+	}
+	return merged
+}
+
+// pick moves a random line of samples into picked
+func pick(samples, picked []string) ([]string, []string) {
+	i := rand.Intn(len(samples))
+	picked = append(picked, samples[i])
+	samples[i] = samples[len(samples)-1]
+	return samples[:len(samples)-1], picked
+}
+
+// MaxUnknowLogSamples is the max number of distinct messages kept for every unknow log
+const MaxUnknowLogSamples = 3
+
+// MergeUnknowLogSamples appends the messages that are not sampled yet until there are MaxUnknowLogSamples samples
+func MergeUnknowLogSamples(samples []string, msgs ...string) []string {
+	for _, msg := range msgs {
+		if len(samples) >= MaxUnknowLogSamples {
+			break
+		}
+
+		sampled := false
+		for _, sample := range samples {
+			if sample == msg {
+				sampled = true
+				break
+			}
+		}
+		if !sampled {
+			samples = append(samples, msg)
+		}
+	}
+	return samples
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"fmt"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testSampleSuite{})
+
+type testSampleSuite struct {
+}
+
+func (t *testSampleSuite) TestSample(c *C) {
+	var samples []string
+	for i := 1; i <= 100; i++ {
+		samples = Sample(samples, int64(i), fmt.Sprint(i), 3)
+		if i <= 3 {
+			c.Assert(samples, HasLen, i)
+		}
+	}
+	c.Assert(samples, HasLen, 3)
+	c.Assert(Sample(nil, 1, "1", 0), HasLen, 0)
+}
+
+func (t *testSampleSuite) TestMergeSamples(c *C) {
+	c.Assert(MergeSamples(nil, 0, []string{"b"}, 1, 3), DeepEquals, []string{"b"})
+	merged := MergeSamples([]string{"a1", "a2"}, 2, []string{"b1"}, 1, 3)
+	c.Assert(merged, HasLen, 3)
+
+	// the lines are picked by the number of lines they represent
+	a, b := []string{"a1", "a2", "a3"}, []string{"b1", "b2", "b3"}
+	fromA := 0
+	for i := 0; i < 1000; i++ {
+		for _, line := range MergeSamples(a, 9000, b, 1000, 3) {
+			if line[0] == 'a' {
+				fromA++
+			}
+		}
+	}
+	c.Assert(fromA > 2500, IsTrue, Commentf("%d lines of 3000 are picked from a", fromA))
+	c.Assert(a, DeepEquals, []string{"a1", "a2", "a3"})
+}
+
+func (t *testSampleSuite) TestMergeUnknowLogSamples(c *C) {
+	c.Assert(MergeUnknowLogSamples(nil, "a", "b", "a"), DeepEquals, []string{"a", "b"})
+	c.Assert(MergeUnknowLogSamples([]string{"a", "b"}, "b", "c", "d"), DeepEquals, []string{"a", "b", "c"})
+	c.Assert(MergeUnknowLogSamples([]string{"a", "b", "c"}, "d"), DeepEquals, []string{"a", "b", "c"})
+}
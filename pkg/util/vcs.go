@@ -88,3 +88,9 @@ func expand(match map[string]string, s string) string {
 func PosToStr(pos *proto.Position) string {
 	return fmt.Sprintf("%s:%s:%d:%d", pos.PackagePath.Repo, pos.FilePath, pos.LineNumber, pos.ColumnOffset)
 }
+
+// UnknowLogToStr converts the identity of *logpattern_go_proto.UnknowLogPattern into a string, the unknow logs
+// are identified by level and position, or by level and message if the position is unknown
+func UnknowLogToStr(unknow *proto.UnknowLogPattern) string {
+	return fmt.Sprintf("%s:%q:%q", unknow.Level, unknow.Pos.FilePath, unknow.Msg)
+}
@@ -161,6 +161,8 @@ type FuncInfo struct {
 	Pos *Position `protobuf:"bytes,2,opt,name=pos,proto3" json:"pos,omitempty"`
 	// Function code
 	Code []byte `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	// The line number where the function ends, 1-based.
+	EndLineNumber int32 `protobuf:"varint,4,opt,name=end_line_number,json=endLineNumber,proto3" json:"end_line_number,omitempty"`
 }
 
 func (m *FuncInfo) Reset()         { *m = FuncInfo{} }
@@ -217,6 +219,13 @@ func (m *FuncInfo) GetCode() []byte {
 	return nil
 }
 
+func (m *FuncInfo) GetEndLineNumber() int32 {
+	if m != nil {
+		return m.EndLineNumber
+	}
+	return 0
+}
+
 // A LogPattern represents a log in code file
 type LogPattern struct {
 	// log position
@@ -228,6 +237,8 @@ type LogPattern struct {
 	// used to quickly identify the log,
 	// e.g. the `format` field of Printf(format string, v ...interface{}) in
 	Signature []string `protobuf:"bytes,4,rep,name=signature,proto3" json:"signature,omitempty"`
+	// the loops around the log, it's empty if the log isn't printed in loops
+	Loop *LoopInfo `protobuf:"bytes,5,opt,name=loop,proto3" json:"loop,omitempty"`
 }
 
 func (m *LogPattern) Reset()         { *m = LogPattern{} }
@@ -291,21 +302,102 @@ func (m *LogPattern) GetSignature() []string {
 	return nil
 }
 
+func (m *LogPattern) GetLoop() *LoopInfo {
+	if m != nil {
+		return m.Loop
+	}
+	return nil
+}
+
+// A LoopInfo tells how a log is nested in loops
+type LoopInfo struct {
+	// the nesting depth of loops, including the loops around the calls
+	// of the enclosing function in the same package
+	Depth int32 `protobuf:"varint,1,opt,name=depth,proto3" json:"depth,omitempty"`
+	// whether the log is guarded by a rate limiter or sampling condition inside the loops
+	Guarded bool `protobuf:"varint,2,opt,name=guarded,proto3" json:"guarded,omitempty"`
+	// the functions from the one that has the outermost loop to the one that prints the log,
+	// it's empty if the loops are in the function that prints the log
+	Via []string `protobuf:"bytes,3,rep,name=via,proto3" json:"via,omitempty"`
+}
+
+func (m *LoopInfo) Reset()         { *m = LoopInfo{} }
+func (m *LoopInfo) String() string { return proto.CompactTextString(m) }
+func (*LoopInfo) ProtoMessage()    {}
+func (*LoopInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a35be2004e1e167, []int{4}
+}
+func (m *LoopInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LoopInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LoopInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LoopInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoopInfo.Merge(m, src)
+}
+func (m *LoopInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *LoopInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoopInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoopInfo proto.InternalMessageInfo
+
+func (m *LoopInfo) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *LoopInfo) GetGuarded() bool {
+	if m != nil {
+		return m.Guarded
+	}
+	return false
+}
+
+func (m *LoopInfo) GetVia() []string {
+	if m != nil {
+		return m.Via
+	}
+	return nil
+}
+
 // Coverage data
 type Coverage struct {
 	// code position
 	Pos *Position `protobuf:"bytes,1,opt,name=pos,proto3" json:"pos,omitempty"`
 	// total count to be covered
-	CovCount int32 `protobuf:"varint,2,opt,name=cov_count,json=covCount,proto3" json:"cov_count,omitempty"`
+	CovCount int64 `protobuf:"varint,2,opt,name=cov_count,json=covCount,proto3" json:"cov_count,omitempty"`
 	// the count to be covered in every file
-	CovCountByLog map[string]int32 `protobuf:"bytes,3,rep,name=cov_count_by_log,json=covCountByLog,proto3" json:"cov_count_by_log,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	CovCountByLog map[string]int64 `protobuf:"bytes,3,rep,name=cov_count_by_log,json=covCountByLog,proto3" json:"cov_count_by_log,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// the count to be covered by the logs that also matched other log patterns
+	AmbiguousCount int64 `protobuf:"varint,4,opt,name=ambiguous_count,json=ambiguousCount,proto3" json:"ambiguous_count,omitempty"`
+	// the raw log lines sampled uniformly from the logs that covered the pattern
+	Samples []string `protobuf:"bytes,5,rep,name=samples,proto3" json:"samples,omitempty"`
+	// the unix time in nanoseconds of the earliest and the latest logs that covered the pattern,
+	// they are zero if the time of logs can't be parsed
+	FirstSeen int64 `protobuf:"varint,6,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen  int64 `protobuf:"varint,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
 }
 
 func (m *Coverage) Reset()         { *m = Coverage{} }
 func (m *Coverage) String() string { return proto.CompactTextString(m) }
 func (*Coverage) ProtoMessage()    {}
 func (*Coverage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a35be2004e1e167, []int{4}
+	return fileDescriptor_9a35be2004e1e167, []int{5}
 }
 func (m *Coverage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -341,20 +433,48 @@ func (m *Coverage) GetPos() *Position {
 	return nil
 }
 
-func (m *Coverage) GetCovCount() int32 {
+func (m *Coverage) GetCovCount() int64 {
 	if m != nil {
 		return m.CovCount
 	}
 	return 0
 }
 
-func (m *Coverage) GetCovCountByLog() map[string]int32 {
+func (m *Coverage) GetCovCountByLog() map[string]int64 {
 	if m != nil {
 		return m.CovCountByLog
 	}
 	return nil
 }
 
+func (m *Coverage) GetAmbiguousCount() int64 {
+	if m != nil {
+		return m.AmbiguousCount
+	}
+	return 0
+}
+
+func (m *Coverage) GetSamples() []string {
+	if m != nil {
+		return m.Samples
+	}
+	return nil
+}
+
+func (m *Coverage) GetFirstSeen() int64 {
+	if m != nil {
+		return m.FirstSeen
+	}
+	return 0
+}
+
+func (m *Coverage) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
 // An UnknowLogPattern represents a log pattern that not captured by log extractor
 // but exits in log
 type UnknowLogPattern struct {
@@ -363,16 +483,20 @@ type UnknowLogPattern struct {
 	// log level "info,warn,error" if has
 	Level string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
 	// total count to be covered
-	CovCount int32 `protobuf:"varint,3,opt,name=cov_count,json=covCount,proto3" json:"cov_count,omitempty"`
+	CovCount int64 `protobuf:"varint,3,opt,name=cov_count,json=covCount,proto3" json:"cov_count,omitempty"`
 	// the count to be covered in every file
-	CovCountByLog map[string]int32 `protobuf:"bytes,4,rep,name=cov_count_by_log,json=covCountByLog,proto3" json:"cov_count_by_log,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	CovCountByLog map[string]int64 `protobuf:"bytes,4,rep,name=cov_count_by_log,json=covCountByLog,proto3" json:"cov_count_by_log,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// the first messages of log, they help to locate the log in code
+	Samples []string `protobuf:"bytes,5,rep,name=samples,proto3" json:"samples,omitempty"`
+	// the message of log if the position is unknown, the logs without position are distinguished by level and message
+	Msg string `protobuf:"bytes,6,opt,name=msg,proto3" json:"msg,omitempty"`
 }
 
 func (m *UnknowLogPattern) Reset()         { *m = UnknowLogPattern{} }
 func (m *UnknowLogPattern) String() string { return proto.CompactTextString(m) }
 func (*UnknowLogPattern) ProtoMessage()    {}
 func (*UnknowLogPattern) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a35be2004e1e167, []int{5}
+	return fileDescriptor_9a35be2004e1e167, []int{6}
 }
 func (m *UnknowLogPattern) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -415,20 +539,34 @@ func (m *UnknowLogPattern) GetLevel() string {
 	return ""
 }
 
-func (m *UnknowLogPattern) GetCovCount() int32 {
+func (m *UnknowLogPattern) GetCovCount() int64 {
 	if m != nil {
 		return m.CovCount
 	}
 	return 0
 }
 
-func (m *UnknowLogPattern) GetCovCountByLog() map[string]int32 {
+func (m *UnknowLogPattern) GetCovCountByLog() map[string]int64 {
 	if m != nil {
 		return m.CovCountByLog
 	}
 	return nil
 }
 
+func (m *UnknowLogPattern) GetSamples() []string {
+	if m != nil {
+		return m.Samples
+	}
+	return nil
+}
+
+func (m *UnknowLogPattern) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
 // LogPatternRule is used to filter log printing pattern in the code,
 // which is referred to as log pattern.
 // usage:
@@ -443,7 +581,7 @@ func (m *LogPatternRule) Reset()         { *m = LogPatternRule{} }
 func (m *LogPatternRule) String() string { return proto.CompactTextString(m) }
 func (*LogPatternRule) ProtoMessage()    {}
 func (*LogPatternRule) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a35be2004e1e167, []int{6}
+	return fileDescriptor_9a35be2004e1e167, []int{7}
 }
 func (m *LogPatternRule) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -486,317 +624,467 @@ func (m *LogPatternRule) GetLogSignatures() []string {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*PackagePath)(nil), "logcov.proto.logpattern.PackagePath")
-	proto.RegisterType((*Position)(nil), "logcov.proto.logpattern.Position")
-	proto.RegisterType((*FuncInfo)(nil), "logcov.proto.logpattern.FuncInfo")
-	proto.RegisterType((*LogPattern)(nil), "logcov.proto.logpattern.LogPattern")
-	proto.RegisterType((*Coverage)(nil), "logcov.proto.logpattern.Coverage")
-	proto.RegisterMapType((map[string]int32)(nil), "logcov.proto.logpattern.Coverage.CovCountByLogEntry")
-	proto.RegisterType((*UnknowLogPattern)(nil), "logcov.proto.logpattern.UnknowLogPattern")
-	proto.RegisterMapType((map[string]int32)(nil), "logcov.proto.logpattern.UnknowLogPattern.CovCountByLogEntry")
-	proto.RegisterType((*LogPatternRule)(nil), "logcov.proto.logpattern.LogPatternRule")
+// ErrorCode represents an error code registered through terror.New in code,
+// e.g. `ErrWorkerNoStart = New(codeWorkerNoStart, ClassDMWorker, ScopeInternal, LevelHigh, "no mysql source is being handled in the worker", "")`
+type ErrorCode struct {
+	// error code, e.g. 11011
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// error class, e.g. "functional"
+	Class string `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`
+	// error scope, e.g. "internal"
+	Scope string `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	// error level, e.g. "high"
+	Level string `protobuf:"bytes,4,opt,name=level,proto3" json:"level,omitempty"`
+	// the message template of the error
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// the variable name that the error is registered to, e.g. "ErrWorkerNoStart"
+	Name string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	// error code defined position
+	Pos *Position `protobuf:"bytes,7,opt,name=pos,proto3" json:"pos,omitempty"`
 }
 
-func init() { proto.RegisterFile("logpattern.proto", fileDescriptor_9a35be2004e1e167) }
-
-var fileDescriptor_9a35be2004e1e167 = []byte{
-	// 542 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x53, 0xcf, 0xaa, 0xd3, 0x4e,
-	0x14, 0xee, 0x34, 0xed, 0x8f, 0xe4, 0xa4, 0xbd, 0x94, 0xf9, 0x09, 0x06, 0xaf, 0xc4, 0x1a, 0x15,
-	0xba, 0xb1, 0x8b, 0x5e, 0x2f, 0x88, 0xb8, 0x90, 0x7b, 0x51, 0x11, 0x8a, 0x96, 0x51, 0x37, 0x82,
-	0x84, 0x34, 0x4e, 0xe7, 0x96, 0x4e, 0xe7, 0x84, 0x34, 0x89, 0xf4, 0x2d, 0x7c, 0x07, 0xb7, 0xae,
-	0x7d, 0x06, 0x97, 0x77, 0xe9, 0x52, 0xda, 0xe7, 0x10, 0x64, 0x26, 0xfd, 0xe7, 0xd5, 0x22, 0x2a,
-	0xb8, 0xca, 0x39, 0xdf, 0x39, 0x73, 0xce, 0xf7, 0x7d, 0x33, 0x81, 0x96, 0x44, 0x91, 0x44, 0x59,
-	0xc6, 0x53, 0xd5, 0x4d, 0x52, 0xcc, 0x90, 0x5e, 0x96, 0x28, 0x62, 0x2c, 0xca, 0xac, 0xbb, 0x2d,
-	0x07, 0xc7, 0xe0, 0x0e, 0xa2, 0x78, 0x12, 0x09, 0x3e, 0x88, 0xb2, 0x33, 0x4a, 0xa1, 0x96, 0xf2,
-	0x04, 0x3d, 0xd2, 0x26, 0x1d, 0x87, 0x99, 0x58, 0x63, 0x49, 0x94, 0x9d, 0x79, 0xd5, 0x12, 0xd3,
-	0x71, 0xf0, 0x91, 0x80, 0x3d, 0xc0, 0xd9, 0x38, 0x1b, 0xa3, 0xa2, 0x8f, 0xa1, 0x91, 0x94, 0x33,
-	0x42, 0xd3, 0xa8, 0x0f, 0xbb, 0xbd, 0x9b, 0xdd, 0x3d, 0x3b, 0xbb, 0x3b, 0x0b, 0x99, 0x9b, 0xec,
-	0x6c, 0x3f, 0x04, 0x67, 0x34, 0x96, 0xab, 0x29, 0xe5, 0x3a, 0x5b, 0x03, 0xa6, 0x78, 0x0d, 0x5c,
-	0x39, 0x56, 0x3c, 0x54, 0xf9, 0x74, 0xc8, 0x53, 0xcf, 0x6a, 0x93, 0x4e, 0x9d, 0x81, 0x86, 0x9e,
-	0x1a, 0x84, 0xde, 0x80, 0x66, 0x8c, 0x32, 0x9f, 0xaa, 0x10, 0x47, 0xa3, 0x19, 0xcf, 0xbc, 0x9a,
-	0x69, 0x69, 0x94, 0xe0, 0x33, 0x83, 0x05, 0x02, 0xec, 0x47, 0xb9, 0x8a, 0x9f, 0xa8, 0x91, 0x11,
-	0xa6, 0xa2, 0x29, 0x5f, 0x8b, 0xd5, 0x31, 0x3d, 0x02, 0x2b, 0xc1, 0x99, 0x59, 0xee, 0xf6, 0xae,
-	0xef, 0x97, 0xb0, 0xd2, 0xce, 0x74, 0xb7, 0x1e, 0x14, 0xe3, 0x1b, 0x6e, 0x38, 0x35, 0x98, 0x89,
-	0x83, 0x0f, 0x04, 0xa0, 0x8f, 0x62, 0x50, 0x1e, 0x58, 0xcf, 0x25, 0xbf, 0x35, 0xf7, 0x18, 0x6a,
-	0xa3, 0x5c, 0xc5, 0xbf, 0x64, 0xb3, 0x56, 0xc4, 0x4c, 0x3b, 0xbd, 0x04, 0x75, 0xc9, 0x0b, 0x2e,
-	0x0d, 0x1f, 0x87, 0x95, 0x09, 0xbd, 0x0a, 0xce, 0x6c, 0x2c, 0x54, 0x94, 0xe5, 0x29, 0xf7, 0x6a,
-	0x6d, 0xab, 0xe3, 0xb0, 0x2d, 0x10, 0x7c, 0x25, 0x60, 0x9f, 0x62, 0xc1, 0xd3, 0x48, 0xf0, 0x3f,
-	0x23, 0x7b, 0x08, 0x4e, 0x8c, 0x45, 0x18, 0x63, 0xae, 0x32, 0xc3, 0xb8, 0xce, 0xec, 0x18, 0x8b,
-	0x53, 0x9d, 0xd3, 0xd7, 0xd0, 0xda, 0x14, 0xc3, 0xe1, 0x3c, 0x94, 0x28, 0x3c, 0xab, 0x6d, 0x75,
-	0xdc, 0xde, 0x9d, 0xbd, 0xe3, 0xd7, 0x74, 0x74, 0x60, 0xa6, 0x9c, 0xcc, 0xfb, 0x28, 0x1e, 0xaa,
-	0x2c, 0x9d, 0xb3, 0x66, 0xbc, 0x8b, 0x5d, 0x79, 0x00, 0xf4, 0xc7, 0x26, 0xda, 0x02, 0x6b, 0xc2,
-	0xe7, 0xab, 0xeb, 0xd5, 0xa1, 0x76, 0xa6, 0x88, 0x64, 0xce, 0x57, 0xfc, 0xca, 0xe4, 0x5e, 0xf5,
-	0x2e, 0x09, 0xde, 0x57, 0xa1, 0xf5, 0x52, 0x4d, 0x14, 0xbe, 0xfd, 0xdb, 0x4b, 0xdb, 0xb8, 0x5f,
-	0xdd, 0x75, 0xff, 0x3b, 0x77, 0xac, 0x0b, 0xee, 0xf0, 0x9f, 0xb8, 0x53, 0x33, 0xee, 0xdc, 0xdf,
-	0xbb, 0xf4, 0x22, 0xd9, 0x7f, 0xe2, 0xd2, 0x0b, 0x38, 0xd8, 0x6e, 0x64, 0xb9, 0xe4, 0x5a, 0x97,
-	0x44, 0x11, 0x96, 0x8a, 0x89, 0x79, 0x55, 0xb6, 0x44, 0xd1, 0x37, 0xa2, 0x6f, 0xc1, 0x81, 0x2e,
-	0x6e, 0x5e, 0x99, 0xfe, 0xaf, 0x74, 0x47, 0x53, 0xa2, 0x78, 0xbe, 0x01, 0x4f, 0x6e, 0x7f, 0x5a,
-	0xf8, 0xe4, 0x7c, 0xe1, 0x93, 0x2f, 0x0b, 0x9f, 0xbc, 0x5b, 0xfa, 0x95, 0xf3, 0xa5, 0x5f, 0xf9,
-	0xbc, 0xf4, 0x2b, 0xaf, 0xfe, 0xdf, 0x0a, 0x0e, 0x05, 0x86, 0xc6, 0x84, 0xe1, 0x7f, 0xe6, 0x73,
-	0xf4, 0x2d, 0x00, 0x00, 0xff, 0xff, 0xf4, 0xac, 0x77, 0xb5, 0xe6, 0x04, 0x00, 0x00,
+func (m *ErrorCode) Reset()         { *m = ErrorCode{} }
+func (m *ErrorCode) String() string { return proto.CompactTextString(m) }
+func (*ErrorCode) ProtoMessage()    {}
+func (*ErrorCode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a35be2004e1e167, []int{8}
 }
-
-func (m *PackagePath) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ErrorCode) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ErrorCode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ErrorCode.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *PackagePath) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ErrorCode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorCode.Merge(m, src)
 }
-
-func (m *PackagePath) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Path) > 0 {
-		i -= len(m.Path)
-		copy(dAtA[i:], m.Path)
-		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Path)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Repo) > 0 {
-		i -= len(m.Repo)
-		copy(dAtA[i:], m.Repo)
-		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Repo)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *ErrorCode) XXX_Size() int {
+	return m.Size()
+}
+func (m *ErrorCode) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorCode.DiscardUnknown(m)
 }
 
-func (m *Position) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ErrorCode proto.InternalMessageInfo
+
+func (m *ErrorCode) GetCode() int32 {
+	if m != nil {
+		return m.Code
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Position) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ErrorCode) GetClass() string {
+	if m != nil {
+		return m.Class
+	}
+	return ""
 }
 
-func (m *Position) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.ColumnOffset != 0 {
-		i = encodeVarintLogpattern(dAtA, i, uint64(m.ColumnOffset))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.LineNumber != 0 {
-		i = encodeVarintLogpattern(dAtA, i, uint64(m.LineNumber))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.FilePath) > 0 {
-		i -= len(m.FilePath)
-		copy(dAtA[i:], m.FilePath)
-		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.FilePath)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.PackagePath != nil {
-		{
-			size, err := m.PackagePath.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintLogpattern(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (m *ErrorCode) GetScope() string {
+	if m != nil {
+		return m.Scope
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *FuncInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ErrorCode) GetLevel() string {
+	if m != nil {
+		return m.Level
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *FuncInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ErrorCode) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
 }
 
-func (m *FuncInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Code) > 0 {
-		i -= len(m.Code)
-		copy(dAtA[i:], m.Code)
-		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Code)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Pos != nil {
-		{
-			size, err := m.Pos.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintLogpattern(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0xa
+func (m *ErrorCode) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *LogPattern) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ErrorCode) GetPos() *Position {
+	if m != nil {
+		return m.Pos
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *LogPattern) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// ErrorCodeCoverage represents how many times an error code appears in logs
+type ErrorCodeCoverage struct {
+	// error code, e.g. 11011
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// total count to be covered
+	CovCount int64 `protobuf:"varint,2,opt,name=cov_count,json=covCount,proto3" json:"cov_count,omitempty"`
+	// the count to be covered in every file
+	CovCountByLog map[string]int64 `protobuf:"bytes,3,rep,name=cov_count_by_log,json=covCountByLog,proto3" json:"cov_count_by_log,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 }
 
-func (m *LogPattern) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Signature) > 0 {
-		for iNdEx := len(m.Signature) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Signature[iNdEx])
-			copy(dAtA[i:], m.Signature[iNdEx])
-			i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Signature[iNdEx])))
-			i--
-			dAtA[i] = 0x22
+func (m *ErrorCodeCoverage) Reset()         { *m = ErrorCodeCoverage{} }
+func (m *ErrorCodeCoverage) String() string { return proto.CompactTextString(m) }
+func (*ErrorCodeCoverage) ProtoMessage()    {}
+func (*ErrorCodeCoverage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a35be2004e1e167, []int{9}
+}
+func (m *ErrorCodeCoverage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ErrorCodeCoverage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ErrorCodeCoverage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.Level) > 0 {
-		i -= len(m.Level)
-		copy(dAtA[i:], m.Level)
-		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Level)))
-		i--
-		dAtA[i] = 0x1a
+}
+func (m *ErrorCodeCoverage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorCodeCoverage.Merge(m, src)
+}
+func (m *ErrorCodeCoverage) XXX_Size() int {
+	return m.Size()
+}
+func (m *ErrorCodeCoverage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorCodeCoverage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErrorCodeCoverage proto.InternalMessageInfo
+
+func (m *ErrorCodeCoverage) GetCode() int32 {
+	if m != nil {
+		return m.Code
 	}
-	if m.Func != nil {
-		{
-			size, err := m.Func.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintLogpattern(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	return 0
+}
+
+func (m *ErrorCodeCoverage) GetCovCount() int64 {
+	if m != nil {
+		return m.CovCount
 	}
-	if m.Pos != nil {
-		{
-			size, err := m.Pos.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintLogpattern(dAtA, i, uint64(size))
+	return 0
+}
+
+func (m *ErrorCodeCoverage) GetCovCountByLog() map[string]int64 {
+	if m != nil {
+		return m.CovCountByLog
+	}
+	return nil
+}
+
+// ErrorPathCoverage represents how many times a function appears in
+// the error stack traces (e.g. `errorVerbose` field of zap log) of logs
+type ErrorPathCoverage struct {
+	// function defined position
+	Pos *Position `protobuf:"bytes,1,opt,name=pos,proto3" json:"pos,omitempty"`
+	// total count to be covered
+	CovCount int64 `protobuf:"varint,2,opt,name=cov_count,json=covCount,proto3" json:"cov_count,omitempty"`
+	// the count to be covered in every file
+	CovCountByLog map[string]int64 `protobuf:"bytes,3,rep,name=cov_count_by_log,json=covCountByLog,proto3" json:"cov_count_by_log,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *ErrorPathCoverage) Reset()         { *m = ErrorPathCoverage{} }
+func (m *ErrorPathCoverage) String() string { return proto.CompactTextString(m) }
+func (*ErrorPathCoverage) ProtoMessage()    {}
+func (*ErrorPathCoverage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a35be2004e1e167, []int{10}
+}
+func (m *ErrorPathCoverage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ErrorPathCoverage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ErrorPathCoverage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ErrorPathCoverage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorPathCoverage.Merge(m, src)
+}
+func (m *ErrorPathCoverage) XXX_Size() int {
+	return m.Size()
+}
+func (m *ErrorPathCoverage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorPathCoverage.DiscardUnknown(m)
 }
 
-func (m *Coverage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ErrorPathCoverage proto.InternalMessageInfo
+
+func (m *ErrorPathCoverage) GetPos() *Position {
+	if m != nil {
+		return m.Pos
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Coverage) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ErrorPathCoverage) GetCovCount() int64 {
+	if m != nil {
+		return m.CovCount
+	}
+	return 0
 }
 
-func (m *Coverage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.CovCountByLog) > 0 {
-		for k := range m.CovCountByLog {
-			v := m.CovCountByLog[k]
-			baseI := i
-			i = encodeVarintLogpattern(dAtA, i, uint64(v))
-			i--
-			dAtA[i] = 0x10
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintLogpattern(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintLogpattern(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x1a
+func (m *ErrorPathCoverage) GetCovCountByLog() map[string]int64 {
+	if m != nil {
+		return m.CovCountByLog
+	}
+	return nil
+}
+
+// A SilentErrorPath represents an error checked branch of boundary function,
+// e.g. gRPC handler, goroutine entry or main, that returns without logging the error
+type SilentErrorPath struct {
+	// the position of the if statement that checks the error
+	Pos *Position `protobuf:"bytes,1,opt,name=pos,proto3" json:"pos,omitempty"`
+	// the boundary function the branch belongs to
+	Func *FuncInfo `protobuf:"bytes,2,opt,name=func,proto3" json:"func,omitempty"`
+	// the kind of boundary function, "grpc", "goroutine" or "main"
+	Boundary string `protobuf:"bytes,3,opt,name=boundary,proto3" json:"boundary,omitempty"`
+	// the condition that checks the error, e.g. "err != nil"
+	Cond string `protobuf:"bytes,4,opt,name=cond,proto3" json:"cond,omitempty"`
+}
+
+func (m *SilentErrorPath) Reset()         { *m = SilentErrorPath{} }
+func (m *SilentErrorPath) String() string { return proto.CompactTextString(m) }
+func (*SilentErrorPath) ProtoMessage()    {}
+func (*SilentErrorPath) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a35be2004e1e167, []int{11}
+}
+func (m *SilentErrorPath) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SilentErrorPath) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SilentErrorPath.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.CovCount != 0 {
-		i = encodeVarintLogpattern(dAtA, i, uint64(m.CovCount))
-		i--
-		dAtA[i] = 0x10
+}
+func (m *SilentErrorPath) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SilentErrorPath.Merge(m, src)
+}
+func (m *SilentErrorPath) XXX_Size() int {
+	return m.Size()
+}
+func (m *SilentErrorPath) XXX_DiscardUnknown() {
+	xxx_messageInfo_SilentErrorPath.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SilentErrorPath proto.InternalMessageInfo
+
+func (m *SilentErrorPath) GetPos() *Position {
+	if m != nil {
+		return m.Pos
 	}
-	if m.Pos != nil {
-		{
-			size, err := m.Pos.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintLogpattern(dAtA, i, uint64(size))
+	return nil
+}
+
+func (m *SilentErrorPath) GetFunc() *FuncInfo {
+	if m != nil {
+		return m.Func
+	}
+	return nil
+}
+
+func (m *SilentErrorPath) GetBoundary() string {
+	if m != nil {
+		return m.Boundary
+	}
+	return ""
+}
+
+func (m *SilentErrorPath) GetCond() string {
+	if m != nil {
+		return m.Cond
+	}
+	return ""
+}
+
+// ScanCheckpoint represents the position that a log has been scanned to,
+// re-running scan on the log resumes from it instead of scanning the log again
+type ScanCheckpoint struct {
+	// the log path given to scan
+	LogPath string `protobuf:"bytes,1,opt,name=log_path,json=logPath,proto3" json:"log_path,omitempty"`
+	// the file being scanned, it may be a rotated backup of the log
+	FilePath string `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	// the identity of the file, e.g. "device:inode"
+	FileId string `protobuf:"bytes,3,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// the offset after the last scanned line in the decompressed content of the file
+	Offset int64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (m *ScanCheckpoint) Reset()         { *m = ScanCheckpoint{} }
+func (m *ScanCheckpoint) String() string { return proto.CompactTextString(m) }
+func (*ScanCheckpoint) ProtoMessage()    {}
+func (*ScanCheckpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a35be2004e1e167, []int{12}
+}
+func (m *ScanCheckpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScanCheckpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScanCheckpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ScanCheckpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScanCheckpoint.Merge(m, src)
+}
+func (m *ScanCheckpoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScanCheckpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScanCheckpoint.DiscardUnknown(m)
 }
 
-func (m *UnknowLogPattern) Marshal() (dAtA []byte, err error) {
+var xxx_messageInfo_ScanCheckpoint proto.InternalMessageInfo
+
+func (m *ScanCheckpoint) GetLogPath() string {
+	if m != nil {
+		return m.LogPath
+	}
+	return ""
+}
+
+func (m *ScanCheckpoint) GetFilePath() string {
+	if m != nil {
+		return m.FilePath
+	}
+	return ""
+}
+
+func (m *ScanCheckpoint) GetFileId() string {
+	if m != nil {
+		return m.FileId
+	}
+	return ""
+}
+
+func (m *ScanCheckpoint) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*PackagePath)(nil), "logcov.proto.logpattern.PackagePath")
+	proto.RegisterType((*Position)(nil), "logcov.proto.logpattern.Position")
+	proto.RegisterType((*FuncInfo)(nil), "logcov.proto.logpattern.FuncInfo")
+	proto.RegisterType((*LogPattern)(nil), "logcov.proto.logpattern.LogPattern")
+	proto.RegisterType((*LoopInfo)(nil), "logcov.proto.logpattern.LoopInfo")
+	proto.RegisterType((*Coverage)(nil), "logcov.proto.logpattern.Coverage")
+	proto.RegisterMapType((map[string]int64)(nil), "logcov.proto.logpattern.Coverage.CovCountByLogEntry")
+	proto.RegisterType((*UnknowLogPattern)(nil), "logcov.proto.logpattern.UnknowLogPattern")
+	proto.RegisterMapType((map[string]int64)(nil), "logcov.proto.logpattern.UnknowLogPattern.CovCountByLogEntry")
+	proto.RegisterType((*LogPatternRule)(nil), "logcov.proto.logpattern.LogPatternRule")
+	proto.RegisterType((*ErrorCode)(nil), "logcov.proto.logpattern.ErrorCode")
+	proto.RegisterType((*ErrorCodeCoverage)(nil), "logcov.proto.logpattern.ErrorCodeCoverage")
+	proto.RegisterMapType((map[string]int64)(nil), "logcov.proto.logpattern.ErrorCodeCoverage.CovCountByLogEntry")
+	proto.RegisterType((*ErrorPathCoverage)(nil), "logcov.proto.logpattern.ErrorPathCoverage")
+	proto.RegisterMapType((map[string]int64)(nil), "logcov.proto.logpattern.ErrorPathCoverage.CovCountByLogEntry")
+	proto.RegisterType((*SilentErrorPath)(nil), "logcov.proto.logpattern.SilentErrorPath")
+	proto.RegisterType((*ScanCheckpoint)(nil), "logcov.proto.logpattern.ScanCheckpoint")
+}
+
+func init() { proto.RegisterFile("logpattern.proto", fileDescriptor_9a35be2004e1e167) }
+
+var fileDescriptor_9a35be2004e1e167 = []byte{
+	// 882 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xc9, 0x8e, 0x1b, 0x45,
+	0x18, 0x9e, 0x76, 0x7b, 0xe9, 0xfe, 0x3d, 0x8b, 0x29, 0x10, 0x69, 0x12, 0x30, 0x43, 0xb3, 0xcd,
+	0x05, 0x1f, 0x26, 0x44, 0x42, 0x08, 0x24, 0x14, 0x2b, 0xa0, 0x48, 0x16, 0x8c, 0x6a, 0xe0, 0x82,
+	0x84, 0x5a, 0xe5, 0xee, 0x72, 0x4d, 0x6b, 0xca, 0xf5, 0xb7, 0x7a, 0x31, 0xf2, 0x53, 0x84, 0x17,
+	0xe1, 0xca, 0x13, 0x70, 0xe0, 0x46, 0x8e, 0x1c, 0xd1, 0xcc, 0x9d, 0x27, 0xe0, 0x80, 0xaa, 0x7a,
+	0x71, 0x93, 0x89, 0x19, 0x12, 0xc2, 0x9c, 0xfc, 0x2f, 0xe5, 0xfa, 0xbe, 0x7f, 0xed, 0x82, 0x91,
+	0x44, 0x91, 0xb0, 0x3c, 0xe7, 0xa9, 0x9a, 0x24, 0x29, 0xe6, 0x48, 0x6e, 0x49, 0x14, 0x21, 0xae,
+	0x4a, 0x6d, 0xb2, 0x71, 0xfb, 0xf7, 0x60, 0x78, 0xc2, 0xc2, 0x73, 0x26, 0xf8, 0x09, 0xcb, 0xcf,
+	0x08, 0x81, 0x6e, 0xca, 0x13, 0xf4, 0xac, 0x43, 0xeb, 0xc8, 0xa5, 0x46, 0xd6, 0xb6, 0x84, 0xe5,
+	0x67, 0x5e, 0xa7, 0xb4, 0x69, 0xd9, 0xff, 0xc9, 0x02, 0xe7, 0x04, 0xb3, 0x38, 0x8f, 0x51, 0x91,
+	0x2f, 0x60, 0x37, 0x29, 0xef, 0x08, 0xcc, 0x41, 0xfd, 0xe7, 0xe1, 0xf1, 0x3b, 0x93, 0x2d, 0x98,
+	0x93, 0x16, 0x20, 0x1d, 0x26, 0x2d, 0xf4, 0x3b, 0xe0, 0x2e, 0x62, 0xc9, 0x83, 0x16, 0x9c, 0xa3,
+	0x0d, 0xc6, 0xf9, 0x26, 0x0c, 0x65, 0xac, 0x78, 0xa0, 0x8a, 0xe5, 0x9c, 0xa7, 0x9e, 0x7d, 0x68,
+	0x1d, 0xf5, 0x28, 0x68, 0xd3, 0x97, 0xc6, 0x42, 0xde, 0x86, 0xbd, 0x10, 0x65, 0xb1, 0x54, 0x01,
+	0x2e, 0x16, 0x19, 0xcf, 0xbd, 0xae, 0x39, 0xb2, 0x5b, 0x1a, 0xbf, 0x32, 0x36, 0xff, 0x91, 0x05,
+	0xce, 0xe7, 0x85, 0x0a, 0x1f, 0xaa, 0x85, 0x89, 0x4c, 0xb1, 0x25, 0xaf, 0xa3, 0xd5, 0x32, 0xb9,
+	0x0b, 0x76, 0x82, 0x99, 0x41, 0x1f, 0x1e, 0xbf, 0xb5, 0x3d, 0x86, 0x2a, 0x78, 0xaa, 0x4f, 0xeb,
+	0x8b, 0x42, 0x8c, 0xb8, 0x21, 0xb5, 0x4b, 0x8d, 0x4c, 0xde, 0x83, 0x03, 0xae, 0xa2, 0xa0, 0xcd,
+	0xb9, 0x24, 0xb4, 0xc7, 0x55, 0x34, 0x6b, 0x68, 0xfb, 0x97, 0x16, 0xc0, 0x0c, 0xc5, 0x49, 0x79,
+	0x71, 0x8d, 0x6f, 0x3d, 0x13, 0xfe, 0x3d, 0xe8, 0x2e, 0x0a, 0x15, 0x5e, 0xcb, 0xba, 0x8e, 0x9c,
+	0x9a, 0xe3, 0xe4, 0x15, 0xe8, 0x49, 0xbe, 0xe2, 0xd2, 0xf0, 0x76, 0x69, 0xa9, 0x90, 0xd7, 0xc1,
+	0xcd, 0x62, 0xa1, 0x58, 0x5e, 0xa4, 0xdc, 0xeb, 0x1e, 0xda, 0x47, 0x2e, 0xdd, 0x18, 0x34, 0x94,
+	0x44, 0x4c, 0xbc, 0xde, 0x35, 0x50, 0x33, 0xc4, 0xa4, 0x84, 0xd2, 0xc7, 0xfd, 0x19, 0x38, 0xb5,
+	0x45, 0xc3, 0x46, 0x3c, 0xa9, 0x1a, 0xa5, 0x47, 0x4b, 0x85, 0x78, 0x30, 0x10, 0x05, 0x4b, 0x23,
+	0x1e, 0x99, 0x30, 0x1c, 0x5a, 0xab, 0x64, 0x04, 0xf6, 0x2a, 0x66, 0x9e, 0x6d, 0xa8, 0x68, 0xd1,
+	0xff, 0xb3, 0x03, 0xce, 0x14, 0x57, 0x3c, 0x65, 0x82, 0x3f, 0x5f, 0xc6, 0xee, 0x80, 0x1b, 0xe2,
+	0x2a, 0x08, 0xb1, 0x50, 0xb9, 0xc1, 0xb3, 0xa9, 0x13, 0xe2, 0x6a, 0xaa, 0x75, 0xf2, 0x1d, 0x8c,
+	0x1a, 0x67, 0x30, 0x5f, 0x07, 0x12, 0x85, 0x41, 0x1f, 0x1e, 0x7f, 0xb8, 0xf5, 0xfa, 0x9a, 0xce,
+	0x64, 0x5a, 0xdd, 0x72, 0x7f, 0x3d, 0x43, 0xf1, 0x40, 0xe5, 0xe9, 0x9a, 0xee, 0x85, 0x6d, 0x1b,
+	0x79, 0x1f, 0x0e, 0xd8, 0x72, 0x1e, 0x8b, 0x02, 0x8b, 0xac, 0x62, 0xd0, 0x35, 0x0c, 0xf6, 0x1b,
+	0x73, 0xc9, 0xc3, 0x83, 0x41, 0xc6, 0x96, 0x89, 0xe4, 0x99, 0xd7, 0x33, 0xc1, 0xd7, 0x2a, 0x79,
+	0x03, 0x60, 0x11, 0xa7, 0x59, 0x1e, 0x64, 0x9c, 0x2b, 0xaf, 0x6f, 0xfe, 0xed, 0x1a, 0xcb, 0x29,
+	0xe7, 0x4a, 0x47, 0x27, 0x59, 0xed, 0x1d, 0x94, 0xd1, 0x49, 0x56, 0x3a, 0x6f, 0x7f, 0x06, 0xe4,
+	0x2a, 0x47, 0x9d, 0xe4, 0x73, 0xbe, 0xae, 0x46, 0x41, 0x8b, 0xba, 0x4c, 0x2b, 0x26, 0x0b, 0x5e,
+	0xa5, 0xa7, 0x54, 0x3e, 0xee, 0x7c, 0x64, 0xf9, 0xbf, 0x76, 0x60, 0xf4, 0x8d, 0x3a, 0x57, 0xf8,
+	0xfd, 0x7f, 0x6d, 0xdc, 0xa6, 0x03, 0x3b, 0xed, 0x0e, 0xfc, 0x5b, 0x71, 0xec, 0x27, 0x8a, 0xc3,
+	0x9f, 0x52, 0x9c, 0xae, 0x29, 0xce, 0x27, 0x5b, 0x41, 0x9f, 0x24, 0xfb, 0x2f, 0x8a, 0xb4, 0x3d,
+	0xf7, 0x23, 0xb0, 0x97, 0x99, 0x30, 0x49, 0x77, 0xa9, 0x16, 0x5f, 0x40, 0x46, 0xbf, 0x86, 0xfd,
+	0x0d, 0x3b, 0x5a, 0x48, 0x6e, 0x4a, 0x88, 0x22, 0x28, 0xb3, 0x63, 0x19, 0x06, 0x8e, 0x44, 0x31,
+	0x33, 0x09, 0x7a, 0x17, 0xf6, 0xb5, 0xb3, 0x99, 0x4a, 0xbd, 0xaf, 0xf4, 0x89, 0x3d, 0x89, 0xe2,
+	0xb4, 0x31, 0xfa, 0x3f, 0x5b, 0xe0, 0x3e, 0x48, 0x53, 0x4c, 0xa7, 0x7a, 0x21, 0xd5, 0x4b, 0xaa,
+	0x9c, 0x3a, 0x23, 0x6b, 0x46, 0xa1, 0x64, 0x59, 0x56, 0xe7, 0xdf, 0x28, 0xda, 0x9a, 0x85, 0x98,
+	0xf0, 0x7a, 0x2f, 0x18, 0x65, 0x53, 0xab, 0x6e, 0xbb, 0x56, 0x1e, 0x0c, 0x96, 0x3c, 0xcb, 0x98,
+	0xe0, 0x66, 0x25, 0xb8, 0xb4, 0x56, 0x9b, 0xed, 0xda, 0xbf, 0xba, 0x5d, 0x07, 0xcf, 0xd2, 0x24,
+	0xfe, 0x1f, 0x16, 0xbc, 0xd4, 0x84, 0xd1, 0x8c, 0xfd, 0xd3, 0xc2, 0xf9, 0xc7, 0xa9, 0x5e, 0x6c,
+	0x9d, 0xea, 0x4f, 0xb7, 0x12, 0xb9, 0x02, 0x7b, 0x7d, 0xe7, 0xbc, 0x80, 0x6e, 0x78, 0xd4, 0xa9,
+	0x02, 0xd6, 0x1f, 0xbe, 0xff, 0x71, 0xcf, 0x3d, 0x77, 0x46, 0xda, 0xbc, 0x6e, 0x24, 0x23, 0x3f,
+	0x5a, 0x70, 0x70, 0x1a, 0x4b, 0xae, 0xf2, 0x06, 0xff, 0x46, 0xbf, 0x94, 0xb7, 0xc1, 0x99, 0x63,
+	0xa1, 0x22, 0x96, 0xae, 0xab, 0xa1, 0x68, 0xf4, 0xb2, 0x11, 0x55, 0x54, 0x8d, 0x85, 0x91, 0xfd,
+	0x35, 0xec, 0x9f, 0x86, 0x4c, 0x4d, 0xcf, 0x78, 0x78, 0x9e, 0x60, 0xac, 0x72, 0xf2, 0x1a, 0xe8,
+	0xf1, 0xdd, 0x3c, 0x90, 0x5c, 0x3a, 0x90, 0x28, 0xae, 0x7f, 0xf6, 0xdc, 0x82, 0x81, 0x71, 0xc6,
+	0x51, 0x05, 0xdc, 0xd7, 0xea, 0xc3, 0x88, 0xbc, 0x0a, 0xfd, 0xd6, 0x3b, 0xc7, 0xa6, 0x95, 0x76,
+	0xff, 0x83, 0x5f, 0x2e, 0xc6, 0xd6, 0xe3, 0x8b, 0xb1, 0xf5, 0xfb, 0xc5, 0xd8, 0xfa, 0xe1, 0x72,
+	0xbc, 0xf3, 0xf8, 0x72, 0xbc, 0xf3, 0xdb, 0xe5, 0x78, 0xe7, 0xdb, 0x97, 0x37, 0xf1, 0x05, 0x02,
+	0x03, 0x13, 0xf3, 0xbc, 0x6f, 0x7e, 0xee, 0xfe, 0x35, 0x00, 0x33, 0xbe, 0xdf, 0x82, 0x34, 0x0a,
+	0x00, 0x00,
+}
+
+func (m *PackagePath) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -806,48 +1094,73 @@ func (m *UnknowLogPattern) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UnknowLogPattern) MarshalTo(dAtA []byte) (int, error) {
+func (m *PackagePath) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *UnknowLogPattern) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PackagePath) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.CovCountByLog) > 0 {
-		for k := range m.CovCountByLog {
-			v := m.CovCountByLog[k]
-			baseI := i
-			i = encodeVarintLogpattern(dAtA, i, uint64(v))
-			i--
-			dAtA[i] = 0x10
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintLogpattern(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintLogpattern(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x22
-		}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.CovCount != 0 {
-		i = encodeVarintLogpattern(dAtA, i, uint64(m.CovCount))
+	if len(m.Repo) > 0 {
+		i -= len(m.Repo)
+		copy(dAtA[i:], m.Repo)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Repo)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Position) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Position) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Position) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ColumnOffset != 0 {
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.ColumnOffset))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.LineNumber != 0 {
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.LineNumber))
 		i--
 		dAtA[i] = 0x18
 	}
-	if len(m.Level) > 0 {
-		i -= len(m.Level)
-		copy(dAtA[i:], m.Level)
-		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Level)))
+	if len(m.FilePath) > 0 {
+		i -= len(m.FilePath)
+		copy(dAtA[i:], m.FilePath)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.FilePath)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if m.Pos != nil {
+	if m.PackagePath != nil {
 		{
-			size, err := m.Pos.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.PackagePath.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -860,7 +1173,7 @@ func (m *UnknowLogPattern) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *LogPatternRule) Marshal() (dAtA []byte, err error) {
+func (m *FuncInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -870,247 +1183,2466 @@ func (m *LogPatternRule) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LogPatternRule) MarshalTo(dAtA []byte) (int, error) {
+func (m *FuncInfo) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *LogPatternRule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *FuncInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.LogSignatures) > 0 {
-		for iNdEx := len(m.LogSignatures) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.LogSignatures[iNdEx])
-			copy(dAtA[i:], m.LogSignatures[iNdEx])
-			i = encodeVarintLogpattern(dAtA, i, uint64(len(m.LogSignatures[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
+	if m.EndLineNumber != 0 {
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.EndLineNumber))
+		i--
+		dAtA[i] = 0x20
 	}
-	if len(m.LogLevel) > 0 {
-		for iNdEx := len(m.LogLevel) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.LogLevel[iNdEx])
-			copy(dAtA[i:], m.LogLevel[iNdEx])
-			i = encodeVarintLogpattern(dAtA, i, uint64(len(m.LogLevel[iNdEx])))
-			i--
-			dAtA[i] = 0xa
+	if len(m.Code) > 0 {
+		i -= len(m.Code)
+		copy(dAtA[i:], m.Code)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Code)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Pos != nil {
+		{
+			size, err := m.Pos.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogpattern(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintLogpattern(dAtA []byte, offset int, v uint64) int {
-	offset -= sovLogpattern(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *LogPattern) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *PackagePath) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Repo)
-	if l > 0 {
-		n += 1 + l + sovLogpattern(uint64(l))
-	}
-	l = len(m.Path)
-	if l > 0 {
-		n += 1 + l + sovLogpattern(uint64(l))
-	}
-	return n
+
+func (m *LogPattern) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Position) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *LogPattern) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.PackagePath != nil {
-		l = m.PackagePath.Size()
-		n += 1 + l + sovLogpattern(uint64(l))
+	if m.Loop != nil {
+		{
+			size, err := m.Loop.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogpattern(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
 	}
-	l = len(m.FilePath)
-	if l > 0 {
-		n += 1 + l + sovLogpattern(uint64(l))
+	if len(m.Signature) > 0 {
+		for iNdEx := len(m.Signature) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Signature[iNdEx])
+			copy(dAtA[i:], m.Signature[iNdEx])
+			i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Signature[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
 	}
-	if m.LineNumber != 0 {
-		n += 1 + sovLogpattern(uint64(m.LineNumber))
+	if len(m.Level) > 0 {
+		i -= len(m.Level)
+		copy(dAtA[i:], m.Level)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Level)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.ColumnOffset != 0 {
-		n += 1 + sovLogpattern(uint64(m.ColumnOffset))
+	if m.Func != nil {
+		{
+			size, err := m.Func.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogpattern(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if m.Pos != nil {
+		{
+			size, err := m.Pos.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogpattern(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *FuncInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *LoopInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *LoopInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LoopInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovLogpattern(uint64(l))
+	if len(m.Via) > 0 {
+		for iNdEx := len(m.Via) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Via[iNdEx])
+			copy(dAtA[i:], m.Via[iNdEx])
+			i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Via[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	if m.Pos != nil {
-		l = m.Pos.Size()
-		n += 1 + l + sovLogpattern(uint64(l))
+	if m.Guarded {
+		i--
+		if m.Guarded {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
 	}
-	l = len(m.Code)
-	if l > 0 {
-		n += 1 + l + sovLogpattern(uint64(l))
+	if m.Depth != 0 {
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.Depth))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LogPattern) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Pos != nil {
-		l = m.Pos.Size()
-		n += 1 + l + sovLogpattern(uint64(l))
+func (m *Coverage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	if m.Func != nil {
-		l = m.Func.Size()
-		n += 1 + l + sovLogpattern(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *Coverage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Coverage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastSeen != 0 {
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.LastSeen))
+		i--
+		dAtA[i] = 0x38
 	}
-	l = len(m.Level)
-	if l > 0 {
-		n += 1 + l + sovLogpattern(uint64(l))
+	if m.FirstSeen != 0 {
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.FirstSeen))
+		i--
+		dAtA[i] = 0x30
 	}
-	if len(m.Signature) > 0 {
-		for _, s := range m.Signature {
-			l = len(s)
-			n += 1 + l + sovLogpattern(uint64(l))
+	if len(m.Samples) > 0 {
+		for iNdEx := len(m.Samples) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Samples[iNdEx])
+			copy(dAtA[i:], m.Samples[iNdEx])
+			i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Samples[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
 		}
 	}
-	return n
-}
-
-func (m *Coverage) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.AmbiguousCount != 0 {
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.AmbiguousCount))
+		i--
+		dAtA[i] = 0x20
 	}
-	var l int
-	_ = l
-	if m.Pos != nil {
-		l = m.Pos.Size()
-		n += 1 + l + sovLogpattern(uint64(l))
+	if len(m.CovCountByLog) > 0 {
+		for k := range m.CovCountByLog {
+			v := m.CovCountByLog[k]
+			baseI := i
+			i = encodeVarintLogpattern(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintLogpattern(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintLogpattern(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
 	if m.CovCount != 0 {
-		n += 1 + sovLogpattern(uint64(m.CovCount))
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.CovCount))
+		i--
+		dAtA[i] = 0x10
 	}
-	if len(m.CovCountByLog) > 0 {
-		for k, v := range m.CovCountByLog {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovLogpattern(uint64(len(k))) + 1 + sovLogpattern(uint64(v))
-			n += mapEntrySize + 1 + sovLogpattern(uint64(mapEntrySize))
+	if m.Pos != nil {
+		{
+			size, err := m.Pos.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogpattern(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *UnknowLogPattern) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *UnknowLogPattern) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *UnknowLogPattern) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UnknowLogPattern) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Pos != nil {
-		l = m.Pos.Size()
-		n += 1 + l + sovLogpattern(uint64(l))
+	if len(m.Msg) > 0 {
+		i -= len(m.Msg)
+		copy(dAtA[i:], m.Msg)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Msg)))
+		i--
+		dAtA[i] = 0x32
 	}
-	l = len(m.Level)
-	if l > 0 {
-		n += 1 + l + sovLogpattern(uint64(l))
+	if len(m.Samples) > 0 {
+		for iNdEx := len(m.Samples) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Samples[iNdEx])
+			copy(dAtA[i:], m.Samples[iNdEx])
+			i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Samples[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.CovCountByLog) > 0 {
+		for k := range m.CovCountByLog {
+			v := m.CovCountByLog[k]
+			baseI := i
+			i = encodeVarintLogpattern(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintLogpattern(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintLogpattern(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x22
+		}
 	}
 	if m.CovCount != 0 {
-		n += 1 + sovLogpattern(uint64(m.CovCount))
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.CovCount))
+		i--
+		dAtA[i] = 0x18
 	}
-	if len(m.CovCountByLog) > 0 {
-		for k, v := range m.CovCountByLog {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovLogpattern(uint64(len(k))) + 1 + sovLogpattern(uint64(v))
-			n += mapEntrySize + 1 + sovLogpattern(uint64(mapEntrySize))
+	if len(m.Level) > 0 {
+		i -= len(m.Level)
+		copy(dAtA[i:], m.Level)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Level)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Pos != nil {
+		{
+			size, err := m.Pos.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogpattern(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LogPatternRule) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *LogPatternRule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *LogPatternRule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogPatternRule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.LogLevel) > 0 {
-		for _, s := range m.LogLevel {
-			l = len(s)
-			n += 1 + l + sovLogpattern(uint64(l))
+	if len(m.LogSignatures) > 0 {
+		for iNdEx := len(m.LogSignatures) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LogSignatures[iNdEx])
+			copy(dAtA[i:], m.LogSignatures[iNdEx])
+			i = encodeVarintLogpattern(dAtA, i, uint64(len(m.LogSignatures[iNdEx])))
+			i--
+			dAtA[i] = 0x12
 		}
 	}
-	if len(m.LogSignatures) > 0 {
-		for _, s := range m.LogSignatures {
-			l = len(s)
-			n += 1 + l + sovLogpattern(uint64(l))
+	if len(m.LogLevel) > 0 {
+		for iNdEx := len(m.LogLevel) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LogLevel[iNdEx])
+			copy(dAtA[i:], m.LogLevel[iNdEx])
+			i = encodeVarintLogpattern(dAtA, i, uint64(len(m.LogLevel[iNdEx])))
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func sovLogpattern(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *ErrorCode) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
-func sozLogpattern(x uint64) (n int) {
-	return sovLogpattern(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *ErrorCode) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (m *PackagePath) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowLogpattern
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+
+func (m *ErrorCode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Pos != nil {
+		{
+			size, err := m.Pos.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
+			i -= size
+			i = encodeVarintLogpattern(dAtA, i, uint64(size))
 		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PackagePath: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Level) > 0 {
+		i -= len(m.Level)
+		copy(dAtA[i:], m.Level)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Level)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Scope) > 0 {
+		i -= len(m.Scope)
+		copy(dAtA[i:], m.Scope)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Scope)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Class) > 0 {
+		i -= len(m.Class)
+		copy(dAtA[i:], m.Class)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Class)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Code != 0 {
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ErrorCodeCoverage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ErrorCodeCoverage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ErrorCodeCoverage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.CovCountByLog) > 0 {
+		for k := range m.CovCountByLog {
+			v := m.CovCountByLog[k]
+			baseI := i
+			i = encodeVarintLogpattern(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintLogpattern(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintLogpattern(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.CovCount != 0 {
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.CovCount))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Code != 0 {
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ErrorPathCoverage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ErrorPathCoverage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ErrorPathCoverage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.CovCountByLog) > 0 {
+		for k := range m.CovCountByLog {
+			v := m.CovCountByLog[k]
+			baseI := i
+			i = encodeVarintLogpattern(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintLogpattern(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintLogpattern(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.CovCount != 0 {
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.CovCount))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Pos != nil {
+		{
+			size, err := m.Pos.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogpattern(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SilentErrorPath) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SilentErrorPath) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SilentErrorPath) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Cond) > 0 {
+		i -= len(m.Cond)
+		copy(dAtA[i:], m.Cond)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Cond)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Boundary) > 0 {
+		i -= len(m.Boundary)
+		copy(dAtA[i:], m.Boundary)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.Boundary)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Func != nil {
+		{
+			size, err := m.Func.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogpattern(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Pos != nil {
+		{
+			size, err := m.Pos.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogpattern(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScanCheckpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScanCheckpoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScanCheckpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Offset != 0 {
+		i = encodeVarintLogpattern(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.FileId) > 0 {
+		i -= len(m.FileId)
+		copy(dAtA[i:], m.FileId)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.FileId)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.FilePath) > 0 {
+		i -= len(m.FilePath)
+		copy(dAtA[i:], m.FilePath)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.FilePath)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.LogPath) > 0 {
+		i -= len(m.LogPath)
+		copy(dAtA[i:], m.LogPath)
+		i = encodeVarintLogpattern(dAtA, i, uint64(len(m.LogPath)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintLogpattern(dAtA []byte, offset int, v uint64) int {
+	offset -= sovLogpattern(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *PackagePath) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Repo)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	return n
+}
+
+func (m *Position) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PackagePath != nil {
+		l = m.PackagePath.Size()
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.FilePath)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	if m.LineNumber != 0 {
+		n += 1 + sovLogpattern(uint64(m.LineNumber))
+	}
+	if m.ColumnOffset != 0 {
+		n += 1 + sovLogpattern(uint64(m.ColumnOffset))
+	}
+	return n
+}
+
+func (m *FuncInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	if m.Pos != nil {
+		l = m.Pos.Size()
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.Code)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	if m.EndLineNumber != 0 {
+		n += 1 + sovLogpattern(uint64(m.EndLineNumber))
+	}
+	return n
+}
+
+func (m *LogPattern) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pos != nil {
+		l = m.Pos.Size()
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	if m.Func != nil {
+		l = m.Func.Size()
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.Level)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	if len(m.Signature) > 0 {
+		for _, s := range m.Signature {
+			l = len(s)
+			n += 1 + l + sovLogpattern(uint64(l))
+		}
+	}
+	if m.Loop != nil {
+		l = m.Loop.Size()
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	return n
+}
+
+func (m *LoopInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Depth != 0 {
+		n += 1 + sovLogpattern(uint64(m.Depth))
+	}
+	if m.Guarded {
+		n += 2
+	}
+	if len(m.Via) > 0 {
+		for _, s := range m.Via {
+			l = len(s)
+			n += 1 + l + sovLogpattern(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Coverage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pos != nil {
+		l = m.Pos.Size()
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	if m.CovCount != 0 {
+		n += 1 + sovLogpattern(uint64(m.CovCount))
+	}
+	if len(m.CovCountByLog) > 0 {
+		for k, v := range m.CovCountByLog {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovLogpattern(uint64(len(k))) + 1 + sovLogpattern(uint64(v))
+			n += mapEntrySize + 1 + sovLogpattern(uint64(mapEntrySize))
+		}
+	}
+	if m.AmbiguousCount != 0 {
+		n += 1 + sovLogpattern(uint64(m.AmbiguousCount))
+	}
+	if len(m.Samples) > 0 {
+		for _, s := range m.Samples {
+			l = len(s)
+			n += 1 + l + sovLogpattern(uint64(l))
+		}
+	}
+	if m.FirstSeen != 0 {
+		n += 1 + sovLogpattern(uint64(m.FirstSeen))
+	}
+	if m.LastSeen != 0 {
+		n += 1 + sovLogpattern(uint64(m.LastSeen))
+	}
+	return n
+}
+
+func (m *UnknowLogPattern) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pos != nil {
+		l = m.Pos.Size()
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.Level)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	if m.CovCount != 0 {
+		n += 1 + sovLogpattern(uint64(m.CovCount))
+	}
+	if len(m.CovCountByLog) > 0 {
+		for k, v := range m.CovCountByLog {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovLogpattern(uint64(len(k))) + 1 + sovLogpattern(uint64(v))
+			n += mapEntrySize + 1 + sovLogpattern(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Samples) > 0 {
+		for _, s := range m.Samples {
+			l = len(s)
+			n += 1 + l + sovLogpattern(uint64(l))
+		}
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	return n
+}
+
+func (m *LogPatternRule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LogLevel) > 0 {
+		for _, s := range m.LogLevel {
+			l = len(s)
+			n += 1 + l + sovLogpattern(uint64(l))
+		}
+	}
+	if len(m.LogSignatures) > 0 {
+		for _, s := range m.LogSignatures {
+			l = len(s)
+			n += 1 + l + sovLogpattern(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ErrorCode) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovLogpattern(uint64(m.Code))
+	}
+	l = len(m.Class)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.Scope)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.Level)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	if m.Pos != nil {
+		l = m.Pos.Size()
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	return n
+}
+
+func (m *ErrorCodeCoverage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovLogpattern(uint64(m.Code))
+	}
+	if m.CovCount != 0 {
+		n += 1 + sovLogpattern(uint64(m.CovCount))
+	}
+	if len(m.CovCountByLog) > 0 {
+		for k, v := range m.CovCountByLog {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovLogpattern(uint64(len(k))) + 1 + sovLogpattern(uint64(v))
+			n += mapEntrySize + 1 + sovLogpattern(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *ErrorPathCoverage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pos != nil {
+		l = m.Pos.Size()
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	if m.CovCount != 0 {
+		n += 1 + sovLogpattern(uint64(m.CovCount))
+	}
+	if len(m.CovCountByLog) > 0 {
+		for k, v := range m.CovCountByLog {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovLogpattern(uint64(len(k))) + 1 + sovLogpattern(uint64(v))
+			n += mapEntrySize + 1 + sovLogpattern(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *SilentErrorPath) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pos != nil {
+		l = m.Pos.Size()
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	if m.Func != nil {
+		l = m.Func.Size()
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.Boundary)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.Cond)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	return n
+}
+
+func (m *ScanCheckpoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LogPath)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.FilePath)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	l = len(m.FileId)
+	if l > 0 {
+		n += 1 + l + sovLogpattern(uint64(l))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovLogpattern(uint64(m.Offset))
+	}
+	return n
+}
+
+func sovLogpattern(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozLogpattern(x uint64) (n int) {
+	return sovLogpattern(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *PackagePath) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogpattern
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PackagePath: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
 			return fmt.Errorf("proto: PackagePath: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Repo = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogpattern(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Position) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogpattern
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Position: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Position: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PackagePath", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PackagePath == nil {
+				m.PackagePath = &PackagePath{}
+			}
+			if err := m.PackagePath.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FilePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FilePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LineNumber", wireType)
+			}
+			m.LineNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LineNumber |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnOffset", wireType)
+			}
+			m.ColumnOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ColumnOffset |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogpattern(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FuncInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogpattern
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FuncInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FuncInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pos", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pos == nil {
+				m.Pos = &Position{}
+			}
+			if err := m.Pos.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Code = append(m.Code[:0], dAtA[iNdEx:postIndex]...)
+			if m.Code == nil {
+				m.Code = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndLineNumber", wireType)
+			}
+			m.EndLineNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndLineNumber |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogpattern(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogPattern) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogpattern
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogPattern: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogPattern: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pos", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pos == nil {
+				m.Pos = &Position{}
+			}
+			if err := m.Pos.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Func", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Func == nil {
+				m.Func = &FuncInfo{}
+			}
+			if err := m.Func.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Level = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Loop", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Loop == nil {
+				m.Loop = &LoopInfo{}
+			}
+			if err := m.Loop.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogpattern(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LoopInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogpattern
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LoopInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LoopInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Depth", wireType)
+			}
+			m.Depth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Depth |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Guarded", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Guarded = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Via", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Via = append(m.Via, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogpattern(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Coverage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogpattern
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Coverage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Coverage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pos", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pos == nil {
+				m.Pos = &Position{}
+			}
+			if err := m.Pos.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CovCount", wireType)
+			}
+			m.CovCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CovCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CovCountByLog", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CovCountByLog == nil {
+				m.CovCountByLog = make(map[string]int64)
+			}
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLogpattern
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLogpattern
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthLogpattern
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthLogpattern
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLogpattern
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipLogpattern(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthLogpattern
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.CovCountByLog[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AmbiguousCount", wireType)
+			}
+			m.AmbiguousCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AmbiguousCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Samples", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Samples = append(m.Samples, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstSeen", wireType)
+			}
+			m.FirstSeen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FirstSeen |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSeen", wireType)
+			}
+			m.LastSeen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastSeen |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogpattern(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UnknowLogPattern) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogpattern
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UnknowLogPattern: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UnknowLogPattern: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pos", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pos == nil {
+				m.Pos = &Position{}
+			}
+			if err := m.Pos.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Level = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CovCount", wireType)
+			}
+			m.CovCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CovCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CovCountByLog", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CovCountByLog == nil {
+				m.CovCountByLog = make(map[string]int64)
+			}
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLogpattern
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLogpattern
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthLogpattern
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthLogpattern
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLogpattern
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipLogpattern(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthLogpattern
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.CovCountByLog[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Samples", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Samples = append(m.Samples, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogpattern(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogPatternRule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogpattern
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogPatternRule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogPatternRule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogLevel", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1138,11 +3670,11 @@ func (m *PackagePath) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Repo = string(dAtA[iNdEx:postIndex])
+			m.LogLevel = append(m.LogLevel, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogSignatures", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1170,7 +3702,7 @@ func (m *PackagePath) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Path = string(dAtA[iNdEx:postIndex])
+			m.LogSignatures = append(m.LogSignatures, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1193,7 +3725,7 @@ func (m *PackagePath) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Position) Unmarshal(dAtA []byte) error {
+func (m *ErrorCode) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1216,17 +3748,17 @@ func (m *Position) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Position: wiretype end group for non-group")
+			return fmt.Errorf("proto: ErrorCode: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Position: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ErrorCode: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PackagePath", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			var msglen int
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogpattern
@@ -1236,31 +3768,14 @@ func (m *Position) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Code |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthLogpattern
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthLogpattern
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.PackagePath == nil {
-				m.PackagePath = &PackagePath{}
-			}
-			if err := m.PackagePath.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FilePath", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Class", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1288,13 +3803,13 @@ func (m *Position) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.FilePath = string(dAtA[iNdEx:postIndex])
+			m.Class = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LineNumber", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scope", wireType)
 			}
-			m.LineNumber = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogpattern
@@ -1304,16 +3819,29 @@ func (m *Position) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LineNumber |= int32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Scope = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ColumnOffset", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
 			}
-			m.ColumnOffset = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogpattern
@@ -1323,64 +3851,27 @@ func (m *Position) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ColumnOffset |= int32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipLogpattern(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthLogpattern
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *FuncInfo) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowLogpattern
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: FuncInfo: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FuncInfo: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Level = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1408,13 +3899,13 @@ func (m *FuncInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pos", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogpattern
@@ -1424,33 +3915,29 @@ func (m *FuncInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthLogpattern
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthLogpattern
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Pos == nil {
-				m.Pos = &Position{}
-			}
-			if err := m.Pos.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pos", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogpattern
@@ -1460,24 +3947,26 @@ func (m *FuncInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthLogpattern
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthLogpattern
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Code = append(m.Code[:0], dAtA[iNdEx:postIndex]...)
-			if m.Code == nil {
-				m.Code = []byte{}
+			if m.Pos == nil {
+				m.Pos = &Position{}
+			}
+			if err := m.Pos.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -1501,7 +3990,7 @@ func (m *FuncInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LogPattern) Unmarshal(dAtA []byte) error {
+func (m *ErrorCodeCoverage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1524,17 +4013,17 @@ func (m *LogPattern) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LogPattern: wiretype end group for non-group")
+			return fmt.Errorf("proto: ErrorCodeCoverage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogPattern: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ErrorCodeCoverage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pos", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			var msglen int
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogpattern
@@ -1544,33 +4033,16 @@ func (m *LogPattern) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Code |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthLogpattern
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthLogpattern
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Pos == nil {
-				m.Pos = &Position{}
-			}
-			if err := m.Pos.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Func", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CovCount", wireType)
 			}
-			var msglen int
+			m.CovCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogpattern
@@ -1580,33 +4052,16 @@ func (m *LogPattern) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.CovCount |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthLogpattern
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthLogpattern
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Func == nil {
-				m.Func = &FuncInfo{}
-			}
-			if err := m.Func.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CovCountByLog", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogpattern
@@ -1616,55 +4071,104 @@ func (m *LogPattern) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthLogpattern
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthLogpattern
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Level = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			if m.CovCountByLog == nil {
+				m.CovCountByLog = make(map[string]int64)
 			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowLogpattern
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLogpattern
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLogpattern
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthLogpattern
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthLogpattern
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLogpattern
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipLogpattern(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthLogpattern
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthLogpattern
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthLogpattern
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Signature = append(m.Signature, string(dAtA[iNdEx:postIndex]))
+			m.CovCountByLog[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1687,7 +4191,7 @@ func (m *LogPattern) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Coverage) Unmarshal(dAtA []byte) error {
+func (m *ErrorPathCoverage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1710,10 +4214,10 @@ func (m *Coverage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Coverage: wiretype end group for non-group")
+			return fmt.Errorf("proto: ErrorPathCoverage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Coverage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ErrorPathCoverage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1766,7 +4270,7 @@ func (m *Coverage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CovCount |= int32(b&0x7F) << shift
+				m.CovCount |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1801,10 +4305,10 @@ func (m *Coverage) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.CovCountByLog == nil {
-				m.CovCountByLog = make(map[string]int32)
+				m.CovCountByLog = make(map[string]int64)
 			}
 			var mapkey string
-			var mapvalue int32
+			var mapvalue int64
 			for iNdEx < postIndex {
 				entryPreIndex := iNdEx
 				var wire uint64
@@ -1862,7 +4366,7 @@ func (m *Coverage) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						mapvalue |= int32(b&0x7F) << shift
+						mapvalue |= int64(b&0x7F) << shift
 						if b < 0x80 {
 							break
 						}
@@ -1905,7 +4409,7 @@ func (m *Coverage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UnknowLogPattern) Unmarshal(dAtA []byte) error {
+func (m *SilentErrorPath) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1928,10 +4432,10 @@ func (m *UnknowLogPattern) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UnknowLogPattern: wiretype end group for non-group")
+			return fmt.Errorf("proto: SilentErrorPath: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UnknowLogPattern: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SilentErrorPath: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1972,9 +4476,9 @@ func (m *UnknowLogPattern) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Func", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogpattern
@@ -1984,29 +4488,33 @@ func (m *UnknowLogPattern) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthLogpattern
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthLogpattern
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Level = string(dAtA[iNdEx:postIndex])
+			if m.Func == nil {
+				m.Func = &FuncInfo{}
+			}
+			if err := m.Func.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CovCount", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Boundary", wireType)
 			}
-			m.CovCount = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogpattern
@@ -2016,16 +4524,29 @@ func (m *UnknowLogPattern) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CovCount |= int32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Boundary = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CovCountByLog", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cond", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogpattern
@@ -2035,104 +4556,23 @@ func (m *UnknowLogPattern) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthLogpattern
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthLogpattern
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.CovCountByLog == nil {
-				m.CovCountByLog = make(map[string]int32)
-			}
-			var mapkey string
-			var mapvalue int32
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowLogpattern
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowLogpattern
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthLogpattern
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthLogpattern
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowLogpattern
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapvalue |= int32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipLogpattern(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthLogpattern
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.CovCountByLog[mapkey] = mapvalue
+			m.Cond = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2155,7 +4595,7 @@ func (m *UnknowLogPattern) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LogPatternRule) Unmarshal(dAtA []byte) error {
+func (m *ScanCheckpoint) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2178,15 +4618,15 @@ func (m *LogPatternRule) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LogPatternRule: wiretype end group for non-group")
+			return fmt.Errorf("proto: ScanCheckpoint: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogPatternRule: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ScanCheckpoint: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogLevel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogPath", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2214,11 +4654,11 @@ func (m *LogPatternRule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LogLevel = append(m.LogLevel, string(dAtA[iNdEx:postIndex]))
+			m.LogPath = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogSignatures", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FilePath", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2246,8 +4686,59 @@ func (m *LogPatternRule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LogSignatures = append(m.LogSignatures, string(dAtA[iNdEx:postIndex]))
+			m.FilePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogpattern
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FileId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogpattern
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLogpattern(dAtA[iNdEx:])
@@ -0,0 +1,50 @@
+package log_reporter
+
+import (
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	matcher "github.com/IANTHEREAL/logutil/scanner/log_match"
+)
+
+// AmbiguityDetail is a group of log patterns that a log may match together,
+// such logs are credited to every pattern of the group and inflate the coverage
+type AmbiguityDetail struct {
+	// Kind is matcher.AmbiguityIdentical or matcher.AmbiguityOverlap
+	Kind string
+	Logs []*LogDetail
+
+	// CovCount is the count that the patterns of the group are credited,
+	// AmbiguousCount is the part of CovCount credited by the logs that matched multiple patterns
+	CovCount, AmbiguousCount int
+}
+
+// Ambiguities returns the groups of log patterns whose signatures are identical or subsume each other,
+// sorted by group size in descending order
+func (c *Coverager) Ambiguities() ([]*AmbiguityDetail, error) {
+	patterns := make([]*logpattern_go_proto.LogPattern, 0, len(c.Details))
+	for _, d := range c.Details {
+		patterns = append(patterns, d.Pattern)
+	}
+
+	groups, err := matcher.FindAmbiguousGroups(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]*AmbiguityDetail, 0, len(groups))
+	for _, g := range groups {
+		detail := &AmbiguityDetail{Kind: g.Kind}
+		for _, bp := range g.Patterns {
+			d := c.Details[bp.ID()]
+			if d == nil {
+				continue
+			}
+			detail.Logs = append(detail.Logs, d)
+			if d.Coverage != nil {
+				detail.CovCount += int(d.Coverage.CovCount)
+				detail.AmbiguousCount += int(d.Coverage.AmbiguousCount)
+			}
+		}
+		details = append(details, detail)
+	}
+	return details, nil
+}
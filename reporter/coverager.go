@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
+	"time"
 
 	"github.com/IANTHEREAL/logutil/pkg/util"
 	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
@@ -30,18 +32,69 @@ func (l *LogDetail) String() string {
 	return fmt.Sprintf(format, l.Pattern.Pos.PackagePath.Repo, l.Pattern.Pos.FilePath, l.Pattern.Pos.LineNumber, l.Pattern.Pos.ColumnOffset, covercount, l.Pattern.Level, l.Pattern.Signature)
 }
 
+// seenTimeFormat is the format of first seen and last seen time of log pattern in reports
+const seenTimeFormat = "2006/01/02 15:04:05.000 -07:00"
+
+// FirstSeen returns the time of the earliest log that covered the pattern, it's empty if the time is unknown
+func (l *LogDetail) FirstSeen() string {
+	if l.Coverage == nil || l.Coverage.FirstSeen == 0 {
+		return ""
+	}
+	return time.Unix(0, l.Coverage.FirstSeen).UTC().Format(seenTimeFormat)
+}
+
+// LastSeen returns the time of the latest log that covered the pattern, it's empty if the time is unknown
+func (l *LogDetail) LastSeen() string {
+	if l.Coverage == nil || l.Coverage.LastSeen == 0 {
+		return ""
+	}
+	return time.Unix(0, l.Coverage.LastSeen).UTC().Format(seenTimeFormat)
+}
+
+// ErrorCodeDetail contains a registered error code and its coverage in logs
+type ErrorCodeDetail struct {
+	ErrorCode *logpattern_go_proto.ErrorCode
+	Coverage  *logpattern_go_proto.ErrorCodeCoverage
+}
+
+// ErrorPathDetail contains a function that appears in error stack traces and its coverage
+type ErrorPathDetail struct {
+	Func     *logpattern_go_proto.FuncInfo
+	Coverage *logpattern_go_proto.ErrorPathCoverage
+}
+
 type Coverager struct {
 	Details map[string]*LogDetail
 
 	Total, Cov int
 
+	// the count that log patterns are credited by logs, and the part of it
+	// credited by the logs that matched multiple patterns
+	Credits, AmbiguousCredits int
+
+	ErrorCodes                   map[int32]*ErrorCodeDetail
+	ErrorCodeTotal, ErrorCodeCov int
+
+	// functions on failure paths, sorted by cover count in descending order
+	ErrorPaths []*ErrorPathDetail
+
+	// logs printed inside loops, sorted by cover count in descending order
+	LoopLogs []*LogDetail
+
+	// error checked branches of boundary functions that return without logging, sorted by position
+	SilentErrorPaths []*logpattern_go_proto.SilentErrorPath
+
+	// logs that are not captured by log extractor, sorted by cover count in descending order
+	UnknowLogs []*logpattern_go_proto.UnknowLogPattern
+
 	store *keyvalue.Store
 }
 
 func NewCoverager(store *keyvalue.Store) (*Coverager, error) {
 	cov := &Coverager{
-		store:   store,
-		Details: make(map[string]*LogDetail),
+		store:      store,
+		Details:    make(map[string]*LogDetail),
+		ErrorCodes: make(map[int32]*ErrorCodeDetail),
 	}
 
 	err := cov.load(context.Background())
@@ -52,6 +105,19 @@ func (c *Coverager) OverallCoverage() (int, int) {
 	return c.Total, c.Cov
 }
 
+// UntriggeredErrorCodes returns registered error codes that never appear in logs, sorted by code
+func (c *Coverager) UntriggeredErrorCodes() []*logpattern_go_proto.ErrorCode {
+	codes := make([]*logpattern_go_proto.ErrorCode, 0, len(c.ErrorCodes))
+	for _, d := range c.ErrorCodes {
+		if d.Coverage == nil {
+			codes = append(codes, d.ErrorCode)
+		}
+	}
+
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+	return codes
+}
+
 func (c *Coverager) load(ctx context.Context) error {
 	err := c.store.ScanLogPattern(ctx, func(_, value []byte) error {
 		lp := &logpattern_go_proto.LogPattern{}
@@ -74,7 +140,7 @@ func (c *Coverager) load(ctx context.Context) error {
 		return err
 	}
 
-	return c.store.ScanLogCoverage(ctx, func(_, value []byte) error {
+	err = c.store.ScanLogCoverage(ctx, func(_, value []byte) error {
 		lp := &logpattern_go_proto.Coverage{}
 		err := lp.Unmarshal(value)
 		if err != nil {
@@ -84,6 +150,8 @@ func (c *Coverager) load(ctx context.Context) error {
 		path := util.PosToStr(lp.Pos)
 		if d := c.Details[path]; d != nil {
 			c.Cov++
+			c.Credits += int(lp.CovCount)
+			c.AmbiguousCredits += int(lp.AmbiguousCount)
 			d.Coverage = lp
 		} else {
 			log.Fatalf("not found reference log %s", lp)
@@ -91,4 +159,185 @@ func (c *Coverager) load(ctx context.Context) error {
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	c.loadLoopLogs()
+
+	err = c.loadErrorCodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.loadErrorPaths(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.loadSilentErrorPaths(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.loadUnknowLogs(ctx)
+}
+
+// loadLoopLogs collects the logs printed inside loops, the high volume ones come first
+func (c *Coverager) loadLoopLogs() {
+	for _, d := range c.Details {
+		if d.Pattern.Loop != nil {
+			c.LoopLogs = append(c.LoopLogs, d)
+		}
+	}
+
+	covCount := func(d *LogDetail) int64 {
+		if d.Coverage == nil {
+			return 0
+		}
+		return d.Coverage.CovCount
+	}
+	sort.Slice(c.LoopLogs, func(i, j int) bool {
+		if covCount(c.LoopLogs[i]) != covCount(c.LoopLogs[j]) {
+			return covCount(c.LoopLogs[i]) > covCount(c.LoopLogs[j])
+		}
+		return util.PosToStr(c.LoopLogs[i].Pattern.Pos) < util.PosToStr(c.LoopLogs[j].Pattern.Pos)
+	})
+}
+
+// loadErrorCodes loads error code catalog and error code coverage,
+// the error codes that are not registered in the codebase are ignored
+func (c *Coverager) loadErrorCodes(ctx context.Context) error {
+	err := c.store.ScanErrorCode(ctx, func(_, value []byte) error {
+		code := &logpattern_go_proto.ErrorCode{}
+		err := code.Unmarshal(value)
+		if err != nil {
+			return err
+		}
+
+		if d := c.ErrorCodes[code.Code]; d == nil {
+			c.ErrorCodeTotal++
+			c.ErrorCodes[code.Code] = &ErrorCodeDetail{
+				ErrorCode: code,
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.store.ScanErrorCodeCoverage(ctx, func(_, value []byte) error {
+		cov := &logpattern_go_proto.ErrorCodeCoverage{}
+		err := cov.Unmarshal(value)
+		if err != nil {
+			return err
+		}
+
+		if d := c.ErrorCodes[cov.Code]; d != nil {
+			c.ErrorCodeCov++
+			d.Coverage = cov
+		}
+
+		return nil
+	})
+}
+
+// loadErrorPaths loads the functions that appear in error stack traces
+func (c *Coverager) loadErrorPaths(ctx context.Context) error {
+	funcs := make(map[string]*logpattern_go_proto.FuncInfo)
+	err := c.store.ScanFunction(ctx, func(_, value []byte) error {
+		fn := &logpattern_go_proto.FuncInfo{}
+		err := fn.Unmarshal(value)
+		if err != nil {
+			return err
+		}
+
+		funcs[util.PosToStr(fn.Pos)] = fn
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.store.ScanErrorPathCoverage(ctx, func(_, value []byte) error {
+		cov := &logpattern_go_proto.ErrorPathCoverage{}
+		err := cov.Unmarshal(value)
+		if err != nil {
+			return err
+		}
+
+		if fn := funcs[util.PosToStr(cov.Pos)]; fn != nil {
+			c.ErrorPaths = append(c.ErrorPaths, &ErrorPathDetail{
+				Func:     fn,
+				Coverage: cov,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(c.ErrorPaths, func(i, j int) bool {
+		if c.ErrorPaths[i].Coverage.CovCount != c.ErrorPaths[j].Coverage.CovCount {
+			return c.ErrorPaths[i].Coverage.CovCount > c.ErrorPaths[j].Coverage.CovCount
+		}
+		return util.PosToStr(c.ErrorPaths[i].Func.Pos) < util.PosToStr(c.ErrorPaths[j].Func.Pos)
+	})
+	return nil
+}
+
+// loadSilentErrorPaths loads the silent error paths found in the codebase
+func (c *Coverager) loadSilentErrorPaths(ctx context.Context) error {
+	err := c.store.ScanSilentErrorPath(ctx, func(_, value []byte) error {
+		path := &logpattern_go_proto.SilentErrorPath{}
+		err := path.Unmarshal(value)
+		if err != nil {
+			return err
+		}
+
+		c.SilentErrorPaths = append(c.SilentErrorPaths, path)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(c.SilentErrorPaths, func(i, j int) bool {
+		a, b := c.SilentErrorPaths[i].Pos, c.SilentErrorPaths[j].Pos
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return a.LineNumber < b.LineNumber
+	})
+	return nil
+}
+
+// loadUnknowLogs loads the logs that exist in log files but are not captured by log extractor,
+// they are usually extractor gaps or logs printed by untracked code
+func (c *Coverager) loadUnknowLogs(ctx context.Context) error {
+	err := c.store.ScanUnknowLog(ctx, func(_, value []byte) error {
+		unknow := &logpattern_go_proto.UnknowLogPattern{}
+		err := unknow.Unmarshal(value)
+		if err != nil {
+			return err
+		}
+
+		c.UnknowLogs = append(c.UnknowLogs, unknow)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(c.UnknowLogs, func(i, j int) bool {
+		if c.UnknowLogs[i].CovCount != c.UnknowLogs[j].CovCount {
+			return c.UnknowLogs[i].CovCount > c.UnknowLogs[j].CovCount
+		}
+		return util.UnknowLogToStr(c.UnknowLogs[i]) < util.UnknowLogToStr(c.UnknowLogs[j])
+	})
+	return nil
 }
@@ -0,0 +1,92 @@
+package matcher
+
+import (
+	"sort"
+
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+)
+
+const (
+	// AmbiguityIdentical means that all patterns of the group have the same signature
+	AmbiguityIdentical = "identical"
+	// AmbiguityOverlap means that the wildcard signatures of the group subsume each other
+	AmbiguityOverlap = "overlap"
+)
+
+// AmbiguousGroup is a group of log patterns that a log may match together,
+// in which case the log is credited to every one of them
+type AmbiguousGroup struct {
+	Kind     string
+	Patterns []*BriefPattern
+}
+
+// FindAmbiguousGroups finds the groups of log patterns whose signatures are identical
+// or whose wildcard signatures subsume each other, only the patterns of the same level are grouped
+// because the log level is matched too.
+// Pattern a subsumes pattern b if the signature of b matches pattern a,
+// the format placeholders of b are matched as plain text so that they can only be matched by wildcards
+func FindAmbiguousGroups(patterns []*logpattern_go_proto.LogPattern) ([]*AmbiguousGroup, error) {
+	trie := NewPatternTrie()
+	bps := make(map[string]*BriefPattern, len(patterns))
+	for _, lp := range patterns {
+		if len(lp.Signature) == 0 {
+			continue
+		}
+		if err := trie.Insert(lp.Signature[0], lp); err != nil {
+			return nil, err
+		}
+		bp := NewBriefPattern(lp)
+		bps[bp.ID()] = bp
+	}
+
+	// union the patterns that match each other into one group
+	parent := make(map[string]string, len(bps))
+	var find func(id string) string
+	find = func(id string) string {
+		if p, ok := parent[id]; ok && p != id {
+			root := find(p)
+			parent[id] = root
+			return root
+		}
+		return id
+	}
+	for id, bp := range bps {
+		res := trie.Match(bp.Pattern().Signature[0], bp.matchedLevel, "")
+		for matched := range res.Patterns {
+			if a, b := find(id), find(matched); a != b {
+				parent[a] = b
+			}
+		}
+	}
+
+	members := make(map[string][]*BriefPattern)
+	for id, bp := range bps {
+		root := find(id)
+		members[root] = append(members[root], bp)
+	}
+
+	var groups []*AmbiguousGroup
+	for _, ps := range members {
+		if len(ps) < 2 {
+			continue
+		}
+		sort.Slice(ps, func(i, j int) bool { return ps[i].ID() < ps[j].ID() })
+
+		kind := AmbiguityIdentical
+		for _, p := range ps[1:] {
+			if p.Pattern().Signature[0] != ps[0].Pattern().Signature[0] {
+				kind = AmbiguityOverlap
+				break
+			}
+		}
+		groups = append(groups, &AmbiguousGroup{Kind: kind, Patterns: ps})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i].Patterns) != len(groups[j].Patterns) {
+			return len(groups[i].Patterns) > len(groups[j].Patterns)
+		}
+		return groups[i].Patterns[0].ID() < groups[j].Patterns[0].ID()
+	})
+	return groups, nil
+}
@@ -0,0 +1,63 @@
+package matcher
+
+import (
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testAmbiguitySuite{})
+
+type testAmbiguitySuite struct {
+}
+
+func (t *testAmbiguitySuite) TestFindAmbiguousGroups(c *C) {
+	newPattern := func(file string, line int32, level, signature string) *logpattern_go_proto.LogPattern {
+		return &logpattern_go_proto.LogPattern{
+			Pos: &logpattern_go_proto.Position{
+				PackagePath: &logpattern_go_proto.PackagePath{Repo: "github.com/pingcap/ticdc/dm"},
+				FilePath:    file,
+				LineNumber:  line,
+			},
+			Level:     level,
+			Signature: []string{signature},
+		}
+	}
+
+	patterns := []*logpattern_go_proto.LogPattern{
+		// identical signatures
+		newPattern("a.go", 1, "error", "\"fail to start task\""),
+		newPattern("b.go", 2, "error", "\"fail to start task\""),
+		// the same signature of another level isn't ambiguous
+		newPattern("c.go", 3, "info", "\"fail to start task\""),
+		// wildcard signatures subsume the other one
+		newPattern("d.go", 4, "error", "\"fail to load %s\""),
+		newPattern("e.go", 5, "error", "\"fail to load config\""),
+		newPattern("f.go", 6, "error", "\"fail to load %s from %s\""),
+		// unique signature
+		newPattern("g.go", 7, "error", "\"task stopped\""),
+	}
+
+	groups, err := FindAmbiguousGroups(patterns)
+	c.Assert(err, IsNil)
+	c.Assert(groups, HasLen, 2)
+
+	ids := func(g *AmbiguousGroup) []string {
+		var res []string
+		for _, p := range g.Patterns {
+			res = append(res, p.ID())
+		}
+		return res
+	}
+
+	c.Assert(groups[0].Kind, Equals, AmbiguityOverlap)
+	c.Assert(ids(groups[0]), DeepEquals, []string{
+		"github.com/pingcap/ticdc/dm:d.go:4:0",
+		"github.com/pingcap/ticdc/dm:e.go:5:0",
+		"github.com/pingcap/ticdc/dm:f.go:6:0",
+	})
+	c.Assert(groups[1].Kind, Equals, AmbiguityIdentical)
+	c.Assert(ids(groups[1]), DeepEquals, []string{
+		"github.com/pingcap/ticdc/dm:a.go:1:0",
+		"github.com/pingcap/ticdc/dm:b.go:2:0",
+	})
+}
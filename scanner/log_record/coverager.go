@@ -4,12 +4,21 @@ import (
 	"context"
 	"sync"
 
+	"github.com/IANTHEREAL/logutil/pkg/util"
 	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
 	matcher "github.com/IANTHEREAL/logutil/scanner/log_match"
 	scanner "github.com/IANTHEREAL/logutil/scanner/log_scan"
 	"github.com/IANTHEREAL/logutil/storage/keyvalue"
 )
 
+// sampleSize is the max number of raw log lines sampled for every log pattern
+var sampleSize = 3
+
+// SetSampleSize sets the max number of raw log lines sampled for every log pattern, the sampling is disabled if it's zero
+func SetSampleSize(size int) {
+	sampleSize = size
+}
+
 type Coverager struct {
 	sync.RWMutex
 	logCoverageCount map[string]*logpattern_go_proto.Coverage
@@ -24,32 +33,63 @@ func NewCoverager(store *keyvalue.Store) *Coverager {
 	}
 }
 
-func (c *Coverager) Record(l *scanner.Log, pattern *matcher.BriefPattern) {
+// Record credits the log to the pattern,
+// ambiguous means that the log also matched other patterns and every one of them is credited
+func (c *Coverager) Record(l *scanner.Log, pattern *matcher.BriefPattern, ambiguous bool) {
 	c.Lock()
 	cov := c.logCoverageCount[pattern.ID()]
 	if cov == nil {
 		cov = &logpattern_go_proto.Coverage{
 			Pos:           pattern.Pattern().GetPos(),
-			CovCountByLog: make(map[string]int32),
+			CovCountByLog: make(map[string]int64),
 		}
 		c.logCoverageCount[pattern.ID()] = cov
 	}
 
 	cov.CovCount = cov.CovCount + 1
+	if ambiguous {
+		cov.AmbiguousCount = cov.AmbiguousCount + 1
+	}
 	if count, ok := cov.CovCountByLog[l.LogPath]; !ok {
 		cov.CovCountByLog[l.LogPath] = 1
 	} else {
 		cov.CovCountByLog[l.LogPath] = count + 1
 	}
+	cov.Samples = util.Sample(cov.Samples, cov.CovCount, l.Raw, sampleSize)
+	if t, ok := l.ParseTime(); ok {
+		cov.FirstSeen, cov.LastSeen = mergeSeen(cov.FirstSeen, cov.LastSeen, t.UnixNano(), t.UnixNano())
+	}
 	c.Unlock()
 }
 
-func (c *Coverager) Flush() error {
+// Flush merges the coverage recorded since last reset into the coverage in store, the merged coverage is written into batch
+func (c *Coverager) Flush(batch *keyvalue.Store) error {
 	c.Lock()
 	defer c.Unlock()
 
 	for _, cov := range c.logCoverageCount {
-		err := c.store.WriteLogCoverage(context.Background(), cov)
+		stored, err := batch.GetLogCoverage(context.Background(), cov.Pos)
+		if err != nil {
+			return err
+		}
+		if stored != nil {
+			// the stored samples are kept if the sampling is disabled or fewer lines are sampled now
+			size := sampleSize
+			if len(stored.Samples) > size {
+				size = len(stored.Samples)
+			}
+			merged := &logpattern_go_proto.Coverage{
+				Pos:            cov.Pos,
+				CovCount:       stored.CovCount + cov.CovCount,
+				CovCountByLog:  mergeCountByLog(stored.CovCountByLog, cov.CovCountByLog),
+				AmbiguousCount: stored.AmbiguousCount + cov.AmbiguousCount,
+				Samples:        util.MergeSamples(stored.Samples, stored.CovCount, cov.Samples, cov.CovCount, size),
+			}
+			merged.FirstSeen, merged.LastSeen = mergeSeen(stored.FirstSeen, stored.LastSeen, cov.FirstSeen, cov.LastSeen)
+			cov = merged
+		}
+
+		err = batch.WriteLogCoverage(context.Background(), cov)
 		if err != nil {
 			return err
 		}
@@ -57,3 +97,32 @@ func (c *Coverager) Flush() error {
 
 	return nil
 }
+
+// Reset clears the coverage recorded, it's called after the flushed coverage is committed
+func (c *Coverager) Reset() {
+	c.Lock()
+	c.logCoverageCount = make(map[string]*logpattern_go_proto.Coverage)
+	c.Unlock()
+}
+
+// mergeCountByLog returns the sum of counts of every log file
+func mergeCountByLog(counts ...map[string]int64) map[string]int64 {
+	merged := make(map[string]int64)
+	for _, count := range counts {
+		for logPath, n := range count {
+			merged[logPath] += n
+		}
+	}
+	return merged
+}
+
+// mergeSeen returns the earliest first seen and the latest last seen time, the zero time is unknown
+func mergeSeen(first, last, otherFirst, otherLast int64) (int64, int64) {
+	if first == 0 || (otherFirst != 0 && otherFirst < first) {
+		first = otherFirst
+	}
+	if otherLast > last {
+		last = otherLast
+	}
+	return first, last
+}
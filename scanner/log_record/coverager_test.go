@@ -0,0 +1,235 @@
+package recorder
+
+import (
+	"context"
+	"errors"
+
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	matcher "github.com/IANTHEREAL/logutil/scanner/log_match"
+	scanner "github.com/IANTHEREAL/logutil/scanner/log_scan"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+	"github.com/IANTHEREAL/logutil/storage/leveldb"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testCoveragerSuite{})
+
+type testCoveragerSuite struct {
+}
+
+// testFailedDB fails to read, so that the flushing fails
+type testFailedDB struct {
+	keyvalue.DB
+}
+
+func (db *testFailedDB) Get(context.Context, []byte, *keyvalue.Options) ([]byte, error) {
+	return nil, errors.New("injected read error")
+}
+
+func testPattern(line int32) *matcher.BriefPattern {
+	return matcher.NewBriefPattern(&logpattern_go_proto.LogPattern{
+		Pos: &logpattern_go_proto.Position{
+			PackagePath: &logpattern_go_proto.PackagePath{Repo: "github.com/pingcap/ticdc/dm"},
+			FilePath:    "dm/worker/server.go",
+			LineNumber:  line,
+		},
+		Level: "error",
+	})
+}
+
+func (t *testCoveragerSuite) TestFlush(c *C) {
+	store := testStore(c)
+	r := NewCoverager(store)
+	pattern := testPattern(12)
+	r.Record(&scanner.Log{LogPath: "dm-worker-1.log"}, pattern, false)
+	r.Record(&scanner.Log{LogPath: "dm-worker-1.log"}, pattern, true)
+	testFlush(c, store, r.Flush, r.Reset)
+
+	// the coverage of flushes is summed
+	r.Record(&scanner.Log{LogPath: "dm-worker-1.log"}, pattern, false)
+	r.Record(&scanner.Log{LogPath: "dm-worker-2.log"}, pattern, true)
+	r.Record(&scanner.Log{LogPath: "dm-worker-2.log"}, testPattern(34), false)
+	testFlush(c, store, r.Flush, r.Reset)
+
+	cov, err := store.GetLogCoverage(context.Background(), pattern.Pattern().Pos)
+	c.Assert(err, IsNil)
+	c.Assert(cov.CovCount, Equals, int64(4))
+	c.Assert(cov.AmbiguousCount, Equals, int64(2))
+	c.Assert(cov.CovCountByLog, DeepEquals, map[string]int64{"dm-worker-1.log": 3, "dm-worker-2.log": 1})
+	cov, err = store.GetLogCoverage(context.Background(), testPattern(34).Pattern().Pos)
+	c.Assert(err, IsNil)
+	c.Assert(cov.CovCount, Equals, int64(1))
+
+	// the coverage isn't flushed again after reset
+	testFlush(c, store, r.Flush, r.Reset)
+	cov, err = store.GetLogCoverage(context.Background(), pattern.Pattern().Pos)
+	c.Assert(err, IsNil)
+	c.Assert(cov.CovCount, Equals, int64(4))
+}
+
+func (t *testCoveragerSuite) TestFlushFailed(c *C) {
+	store := testStore(c)
+	r := NewCoverager(store)
+	pattern := testPattern(12)
+	r.Record(&scanner.Log{LogPath: "dm-worker-1.log"}, pattern, false)
+
+	// the recorded coverage is kept if the flushing fails, and it's flushed next time
+	db, err := leveldb.Open(c.MkDir(), nil)
+	c.Assert(err, IsNil)
+	failed := keyvalue.NewLogPatternStore(&testFailedDB{DB: db})
+	c.Assert(r.Flush(failed.Batch()), ErrorMatches, ".*injected read error")
+	r.Record(&scanner.Log{LogPath: "dm-worker-1.log"}, pattern, false)
+	testFlush(c, store, r.Flush, r.Reset)
+
+	cov, err := store.GetLogCoverage(context.Background(), pattern.Pattern().Pos)
+	c.Assert(err, IsNil)
+	c.Assert(cov.CovCount, Equals, int64(2))
+	c.Assert(cov.CovCountByLog, DeepEquals, map[string]int64{"dm-worker-1.log": 2})
+}
+
+func (t *testCoveragerSuite) TestResetCoverage(c *C) {
+	store := testStore(c)
+	pattern := testPattern(12)
+	c.Assert(store.WriteLogPattern(context.Background(), pattern.Pattern()), IsNil)
+
+	r := NewCoverager(store)
+	r.Record(&scanner.Log{LogPath: "dm-worker.log"}, pattern, false)
+	unknowLogs := NewUnknowLogRecorder()
+	unknowLogs.Record(&scanner.Log{LogPath: "dm-worker.log", Level: "warn", Position: "main.go:1", Msg: `"exit"`})
+	batch := store.Batch()
+	c.Assert(r.Flush(batch), IsNil)
+	c.Assert(unknowLogs.Flush(batch), IsNil)
+	c.Assert(batch.WriteScanCheckpoint(context.Background(), &logpattern_go_proto.ScanCheckpoint{LogPath: "dm-worker.log", FileId: "1:2", Offset: 10}), IsNil)
+
+	// the batch is only visible after commit
+	cov, err := store.GetLogCoverage(context.Background(), pattern.Pattern().Pos)
+	c.Assert(err, IsNil)
+	c.Assert(cov, IsNil)
+	c.Assert(batch.Commit(context.Background()), IsNil)
+
+	count := func(scan func(context.Context, func(key, value []byte) error) error) int {
+		n := 0
+		c.Assert(scan(context.Background(), func(_, _ []byte) error {
+			n++
+			return nil
+		}), IsNil)
+		return n
+	}
+	c.Assert(count(store.ScanLogCoverage), Equals, 1)
+	c.Assert(count(store.ScanUnknowLog), Equals, 1)
+	c.Assert(count(store.ScanScanCheckpoint), Equals, 1)
+
+	// the coverage and checkpoints are dropped, but the log patterns are kept
+	c.Assert(store.ResetCoverage(context.Background()), IsNil)
+	c.Assert(count(store.ScanLogCoverage), Equals, 0)
+	c.Assert(count(store.ScanUnknowLog), Equals, 0)
+	c.Assert(count(store.ScanScanCheckpoint), Equals, 0)
+	c.Assert(count(store.ScanLogPattern), Equals, 1)
+}
+
+func (t *testCoveragerSuite) TestSamples(c *C) {
+	defer SetSampleSize(sampleSize)
+	SetSampleSize(2)
+	store := testStore(c)
+	r := NewCoverager(store)
+	pattern := testPattern(12)
+	sampled := func() []string {
+		cov, err := store.GetLogCoverage(context.Background(), pattern.Pattern().Pos)
+		c.Assert(err, IsNil)
+		return cov.Samples
+	}
+
+	lines := map[string]bool{}
+	for _, line := range []string{"l1", "l2", "l3", "l4", "l5"} {
+		lines[line] = true
+		r.Record(&scanner.Log{LogPath: "dm-worker.log", Raw: line}, pattern, false)
+	}
+	testFlush(c, store, r.Flush, r.Reset)
+	samples := sampled()
+	c.Assert(samples, HasLen, 2)
+	for _, sample := range samples {
+		c.Assert(lines[sample], IsTrue)
+	}
+
+	// the stored samples are kept if the sampling is disabled
+	SetSampleSize(0)
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Raw: "m1"}, pattern, false)
+	testFlush(c, store, r.Flush, r.Reset)
+	c.Assert(sampled(), HasLen, 2)
+	c.Assert(lines[sampled()[0]] && lines[sampled()[1]], IsTrue)
+
+	// the stored samples are kept if fewer lines are sampled now
+	SetSampleSize(1)
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Raw: "n1"}, pattern, false)
+	testFlush(c, store, r.Flush, r.Reset)
+	c.Assert(sampled(), HasLen, 2)
+
+	// the samples are merged up to the sample size
+	SetSampleSize(10)
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Raw: "o1"}, pattern, false)
+	testFlush(c, store, r.Flush, r.Reset)
+	samples = sampled()
+	c.Assert(samples, HasLen, 3)
+	found := false
+	for _, sample := range samples {
+		found = found || sample == "o1"
+	}
+	c.Assert(found, IsTrue)
+}
+
+func (t *testCoveragerSuite) TestSeen(c *C) {
+	store := testStore(c)
+	r := NewCoverager(store)
+	pattern := testPattern(12)
+	seen := func() (int64, int64) {
+		cov, err := store.GetLogCoverage(context.Background(), pattern.Pattern().Pos)
+		c.Assert(err, IsNil)
+		return cov.FirstSeen, cov.LastSeen
+	}
+	unix := func(s string) int64 {
+		tm, ok := (&scanner.Log{Time: s}).ParseTime()
+		c.Assert(ok, IsTrue)
+		return tm.UnixNano()
+	}
+
+	// the logs whose time can't be parsed are not seen
+	r.Record(&scanner.Log{LogPath: "dm-worker.log"}, pattern, false)
+	testFlush(c, store, r.Flush, r.Reset)
+	first, last := seen()
+	c.Assert(first, Equals, int64(0))
+	c.Assert(last, Equals, int64(0))
+
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Time: "2021/11/18 10:00:00.000 +00:00"}, pattern, false)
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Time: "2021/11/18 12:00:00.000 +00:00"}, pattern, false)
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Time: "2021/11/18 11:00:00.000 +00:00"}, pattern, false)
+	testFlush(c, store, r.Flush, r.Reset)
+	first, last = seen()
+	c.Assert(first, Equals, unix("2021/11/18 10:00:00.000 +00:00"))
+	c.Assert(last, Equals, unix("2021/11/18 12:00:00.000 +00:00"))
+
+	// the seen time is merged with the stored one
+	r.Record(&scanner.Log{LogPath: "dm-worker-2.log", Time: "2021/11/17 10:00:00.000 +00:00"}, pattern, false)
+	r.Record(&scanner.Log{LogPath: "dm-worker-2.log"}, pattern, false)
+	testFlush(c, store, r.Flush, r.Reset)
+	first, last = seen()
+	c.Assert(first, Equals, unix("2021/11/17 10:00:00.000 +00:00"))
+	c.Assert(last, Equals, unix("2021/11/18 12:00:00.000 +00:00"))
+}
+
+func (t *testCoveragerSuite) TestMergeSeen(c *C) {
+	cases := []struct {
+		first, last, otherFirst, otherLast int64
+		expectedFirst, expectedLast        int64
+	}{
+		{0, 0, 0, 0, 0, 0},
+		{0, 0, 10, 20, 10, 20},
+		{10, 20, 0, 0, 10, 20},
+		{10, 20, 5, 15, 5, 20},
+		{10, 20, 15, 25, 10, 25},
+	}
+	for _, cs := range cases {
+		first, last := mergeSeen(cs.first, cs.last, cs.otherFirst, cs.otherLast)
+		c.Assert(first, Equals, cs.expectedFirst, Commentf("case %+v", cs))
+		c.Assert(last, Equals, cs.expectedLast, Commentf("case %+v", cs))
+	}
+}
@@ -0,0 +1,122 @@
+package recorder
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"sync"
+
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	scanner "github.com/IANTHEREAL/logutil/scanner/log_scan"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+)
+
+// errorFieldKeys are the log fields that may carry a terror error,
+// e.g. [error="[code=11011:class=functional:scope=internal:level=high], Message: ..."]
+var errorFieldKeys = []string{"error", "err"}
+
+var terrorCodeRegexp = regexp.MustCompile(`\[code=(\d+):class=[^:\]]*:scope=[^:\]]*:level=[^:\]]*\]`)
+
+// ParseErrorCodes returns all distinct error codes in the error message,
+// a wrapped error may carry the codes of its causes too
+func ParseErrorCodes(errMsg string) []int32 {
+	var codes []int32
+	seen := make(map[int32]struct{})
+	for _, m := range terrorCodeRegexp.FindAllStringSubmatch(errMsg, -1) {
+		code, err := strconv.ParseInt(m[1], 10, 32)
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[int32(code)]; !ok {
+			seen[int32(code)] = struct{}{}
+			codes = append(codes, int32(code))
+		}
+	}
+	return codes
+}
+
+// ErrorCodeRecorder used to record how many times error codes appear in the error fields of logs
+type ErrorCodeRecorder struct {
+	sync.RWMutex
+	codes map[int32]*logpattern_go_proto.ErrorCodeCoverage
+
+	store *keyvalue.Store
+}
+
+func NewErrorCodeRecorder(store *keyvalue.Store) *ErrorCodeRecorder {
+	return &ErrorCodeRecorder{
+		store: store,
+		codes: make(map[int32]*logpattern_go_proto.ErrorCodeCoverage),
+	}
+}
+
+func (r *ErrorCodeRecorder) Record(l *scanner.Log) {
+	if len(l.Fields) == 0 {
+		return
+	}
+
+	var codes []int32
+	for _, key := range errorFieldKeys {
+		if errMsg, ok := l.Fields[key]; ok {
+			codes = append(codes, ParseErrorCodes(errMsg)...)
+		}
+	}
+
+	r.Lock()
+	counted := make(map[int32]struct{}, len(codes))
+	for _, code := range codes {
+		// one log only covers an error code once
+		if _, ok := counted[code]; ok {
+			continue
+		}
+		counted[code] = struct{}{}
+
+		cov := r.codes[code]
+		if cov == nil {
+			cov = &logpattern_go_proto.ErrorCodeCoverage{
+				Code:          code,
+				CovCountByLog: make(map[string]int64),
+			}
+			r.codes[code] = cov
+		}
+
+		cov.CovCount = cov.CovCount + 1
+		cov.CovCountByLog[l.LogPath] = cov.CovCountByLog[l.LogPath] + 1
+	}
+	r.Unlock()
+}
+
+// Flush merges the error code coverage recorded since last reset into the coverage in store,
+// the merged coverage is written into batch
+func (r *ErrorCodeRecorder) Flush(batch *keyvalue.Store) error {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, cov := range r.codes {
+		stored, err := batch.GetErrorCodeCoverage(context.Background(), cov.Code)
+		if err != nil {
+			return err
+		}
+		if stored != nil {
+			cov = &logpattern_go_proto.ErrorCodeCoverage{
+				Code:          cov.Code,
+				CovCount:      stored.CovCount + cov.CovCount,
+				CovCountByLog: mergeCountByLog(stored.CovCountByLog, cov.CovCountByLog),
+			}
+		}
+
+		err = batch.WriteErrorCodeCoverage(context.Background(), cov)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reset clears the error code coverage recorded, it's called after the flushed coverage is committed
+func (r *ErrorCodeRecorder) Reset() {
+	r.Lock()
+	r.codes = make(map[int32]*logpattern_go_proto.ErrorCodeCoverage)
+	r.Unlock()
+}
@@ -0,0 +1,81 @@
+package recorder
+
+import (
+	"context"
+	"testing"
+
+	scanner "github.com/IANTHEREAL/logutil/scanner/log_scan"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+	"github.com/IANTHEREAL/logutil/storage/leveldb"
+	. "github.com/pingcap/check"
+)
+
+func TestClient(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testErrorCodeSuite{})
+
+type testErrorCodeSuite struct {
+}
+
+func testStore(c *C) *keyvalue.Store {
+	db, err := leveldb.Open(c.MkDir(), nil)
+	c.Assert(err, IsNil)
+	return keyvalue.NewLogPatternStore(db)
+}
+
+// testFlush flushes the recorder into store in one batch like log processor
+func testFlush(c *C, store *keyvalue.Store, flush func(*keyvalue.Store) error, reset func()) {
+	batch := store.Batch()
+	c.Assert(flush(batch), IsNil)
+	c.Assert(batch.Commit(context.Background()), IsNil)
+	reset()
+}
+
+func (t *testErrorCodeSuite) TestParseErrorCodes(c *C) {
+	cases := []struct {
+		errMsg   string
+		expected []int32
+	}{
+		{"", nil},
+		{"connection refused", nil},
+		{"[code=11011:class=functional:scope=internal:level=high], Message: no mysql source is being handled in the worker", []int32{11011}},
+		// the causes of wrapped error
+		{"[code=38032:class=dm-master:scope=internal:level=high], Message: fail to start task, RawCause: [code=10001:class=database:scope=downstream:level=high], Message: database driver error", []int32{38032, 10001}},
+		// the codes are distinct
+		{"[code=10001:class=database:scope=downstream:level=high] [code=10001:class=database:scope=downstream:level=high]", []int32{10001}},
+		// not a terror error
+		{"[code=10001]", nil},
+		{"[code=99999999999:class=database:scope=downstream:level=high]", nil},
+	}
+	for _, cs := range cases {
+		c.Assert(ParseErrorCodes(cs.errMsg), DeepEquals, cs.expected, Commentf("error %s", cs.errMsg))
+	}
+}
+
+func (t *testErrorCodeSuite) TestRecord(c *C) {
+	store := testStore(c)
+	r := NewErrorCodeRecorder(store)
+	wrapped := "[code=38032:class=dm-master:scope=internal:level=high], Message: fail, RawCause: [code=10001:class=database:scope=downstream:level=high]"
+	r.Record(&scanner.Log{LogPath: "dm-master.log", Fields: map[string]string{"error": wrapped}})
+	// one log only covers an error code once, even if it's in both error fields
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Fields: map[string]string{
+		"error": "[code=10001:class=database:scope=downstream:level=high]",
+		"err":   "[code=10001:class=database:scope=downstream:level=high]",
+	}})
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Fields: map[string]string{"msg": "[code=10002:class=database:scope=downstream:level=high]"}})
+	r.Record(&scanner.Log{LogPath: "dm-worker.log"})
+	testFlush(c, store, r.Flush, r.Reset)
+
+	cov, err := store.GetErrorCodeCoverage(context.Background(), 10001)
+	c.Assert(err, IsNil)
+	c.Assert(cov.CovCount, Equals, int64(2))
+	c.Assert(cov.CovCountByLog, DeepEquals, map[string]int64{"dm-master.log": 1, "dm-worker.log": 1})
+	cov, err = store.GetErrorCodeCoverage(context.Background(), 38032)
+	c.Assert(err, IsNil)
+	c.Assert(cov.CovCount, Equals, int64(1))
+	cov, err = store.GetErrorCodeCoverage(context.Background(), 10002)
+	c.Assert(err, IsNil)
+	c.Assert(cov, IsNil)
+}
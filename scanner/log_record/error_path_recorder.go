@@ -0,0 +1,162 @@
+package recorder
+
+import (
+	"context"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/IANTHEREAL/logutil/pkg/util"
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	scanner "github.com/IANTHEREAL/logutil/scanner/log_scan"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+)
+
+// ErrorPathRecorder used to map the frames of error stack traces in logs onto the functions
+// recorded at extract time, and record how many times every function appears on failure paths
+type ErrorPathRecorder struct {
+	sync.RWMutex
+	paths map[string]*logpattern_go_proto.ErrorPathCoverage
+
+	// file base name -> functions defined in the file
+	funcs map[string][]*logpattern_go_proto.FuncInfo
+
+	store *keyvalue.Store
+}
+
+func NewErrorPathRecorder(store *keyvalue.Store) (*ErrorPathRecorder, error) {
+	r := &ErrorPathRecorder{
+		store: store,
+		paths: make(map[string]*logpattern_go_proto.ErrorPathCoverage),
+		funcs: make(map[string][]*logpattern_go_proto.FuncInfo),
+	}
+
+	err := r.load(context.Background())
+	return r, err
+}
+
+// load reads all functions from the store to build a file name index
+func (r *ErrorPathRecorder) load(ctx context.Context) error {
+	return r.store.ScanFunction(ctx, func(_, value []byte) error {
+		fn := &logpattern_go_proto.FuncInfo{}
+		err := fn.Unmarshal(value)
+		if err != nil {
+			return err
+		}
+
+		base := path.Base(fn.Pos.FilePath)
+		r.funcs[base] = append(r.funcs[base], fn)
+		return nil
+	})
+}
+
+func (r *ErrorPathRecorder) Record(l *scanner.Log) {
+	frames := l.StackTrace()
+	if len(frames) == 0 || len(r.funcs) == 0 {
+		return
+	}
+
+	r.Lock()
+	counted := make(map[string]struct{}, len(frames))
+	for _, frame := range frames {
+		fn := r.lookup(frame)
+		if fn == nil {
+			continue
+		}
+
+		// one log only covers a function once, e.g. recursive calls
+		id := util.PosToStr(fn.Pos)
+		if _, ok := counted[id]; ok {
+			continue
+		}
+		counted[id] = struct{}{}
+
+		cov := r.paths[id]
+		if cov == nil {
+			cov = &logpattern_go_proto.ErrorPathCoverage{
+				Pos:           fn.Pos,
+				CovCountByLog: make(map[string]int64),
+			}
+			r.paths[id] = cov
+		}
+
+		cov.CovCount = cov.CovCount + 1
+		cov.CovCountByLog[l.LogPath] = cov.CovCountByLog[l.LogPath] + 1
+	}
+	r.Unlock()
+}
+
+// lookup finds the function that contains the frame location,
+// the file paths of frame and function are compared by path suffix
+// because they are relative to different roots (e.g. GOPATH or module cache)
+func (r *ErrorPathRecorder) lookup(frame *scanner.StackFrame) *logpattern_go_proto.FuncInfo {
+	file := trimModuleVersion(frame.File)
+	for _, fn := range r.funcs[path.Base(frame.File)] {
+		if int32(frame.Line) < fn.Pos.LineNumber || int32(frame.Line) > fn.EndLineNumber {
+			continue
+		}
+		if isPathSuffix(file, fn.Pos.FilePath) || isPathSuffix(fn.Pos.FilePath, file) {
+			return fn
+		}
+	}
+	return nil
+}
+
+// trimModuleVersion removes the module versions in the path of module cache,
+// e.g. github.com/pingcap/ticdc@v1.2.3/dm/worker/server.go => github.com/pingcap/ticdc/dm/worker/server.go
+func trimModuleVersion(p string) string {
+	if !strings.Contains(p, "@") {
+		return p
+	}
+
+	elems := strings.Split(p, "/")
+	for i, elem := range elems {
+		if at := strings.IndexByte(elem, '@'); at > 0 {
+			elems[i] = elem[:at]
+		}
+	}
+	return strings.Join(elems, "/")
+}
+
+// isPathSuffix returns whether suffix is the suffix of p at path boundary
+func isPathSuffix(p, suffix string) bool {
+	if !strings.HasSuffix(p, suffix) {
+		return false
+	}
+	return len(p) == len(suffix) || p[len(p)-len(suffix)-1] == '/'
+}
+
+// Flush merges the error path coverage recorded since last reset into the coverage in store,
+// the merged coverage is written into batch
+func (r *ErrorPathRecorder) Flush(batch *keyvalue.Store) error {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, cov := range r.paths {
+		stored, err := batch.GetErrorPathCoverage(context.Background(), cov.Pos)
+		if err != nil {
+			return err
+		}
+		if stored != nil {
+			cov = &logpattern_go_proto.ErrorPathCoverage{
+				Pos:           cov.Pos,
+				CovCount:      stored.CovCount + cov.CovCount,
+				CovCountByLog: mergeCountByLog(stored.CovCountByLog, cov.CovCountByLog),
+			}
+		}
+
+		err = batch.WriteErrorPathCoverage(context.Background(), cov)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reset clears the error path coverage recorded, it's called after the flushed coverage is committed
+func (r *ErrorPathRecorder) Reset() {
+	r.Lock()
+	r.paths = make(map[string]*logpattern_go_proto.ErrorPathCoverage)
+	r.Unlock()
+}
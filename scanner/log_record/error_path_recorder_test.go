@@ -0,0 +1,123 @@
+package recorder
+
+import (
+	"context"
+	"fmt"
+
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	scanner "github.com/IANTHEREAL/logutil/scanner/log_scan"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testErrorPathSuite{})
+
+type testErrorPathSuite struct {
+}
+
+func testFunc(name, file string, line, end int32) *logpattern_go_proto.FuncInfo {
+	return &logpattern_go_proto.FuncInfo{
+		Name: name,
+		Pos: &logpattern_go_proto.Position{
+			PackagePath: &logpattern_go_proto.PackagePath{Repo: "github.com/pingcap/ticdc"},
+			FilePath:    file,
+			LineNumber:  line,
+		},
+		EndLineNumber: end,
+	}
+}
+
+func (t *testErrorPathSuite) TestIsPathSuffix(c *C) {
+	cases := []struct {
+		p, suffix string
+		expected  bool
+	}{
+		{"github.com/pingcap/ticdc/dm/worker/server.go", "dm/worker/server.go", true},
+		{"dm/worker/server.go", "dm/worker/server.go", true},
+		{"github.com/pingcap/ticdc/dm/worker/server.go", "worker/server.go", true},
+		// the suffix must start at path boundary
+		{"github.com/pingcap/ticdc/dm/myworker/server.go", "worker/server.go", false},
+		{"dm/worker/server.go", "dm/master/server.go", false},
+		{"server.go", "dm/worker/server.go", false},
+	}
+	for _, cs := range cases {
+		c.Assert(isPathSuffix(cs.p, cs.suffix), Equals, cs.expected, Commentf("%s %s", cs.p, cs.suffix))
+	}
+}
+
+func (t *testErrorPathSuite) TestTrimModuleVersion(c *C) {
+	cases := []struct {
+		p, expected string
+	}{
+		{"github.com/pingcap/ticdc/dm/worker/server.go", "github.com/pingcap/ticdc/dm/worker/server.go"},
+		{"github.com/pingcap/ticdc@v1.2.3/dm/worker/server.go", "github.com/pingcap/ticdc/dm/worker/server.go"},
+		{"/root/go/pkg/mod/github.com/pingcap/ticdc@v0.0.0-20211118-c91af794e65f/dm/worker/server.go", "/root/go/pkg/mod/github.com/pingcap/ticdc/dm/worker/server.go"},
+		{"/root/go/pkg/mod/github.com/pingcap/errors@v0.11.5-0.20211009033009-93128226aaa3/errors.go", "/root/go/pkg/mod/github.com/pingcap/errors/errors.go"},
+	}
+	for _, cs := range cases {
+		c.Assert(trimModuleVersion(cs.p), Equals, cs.expected)
+	}
+}
+
+func (t *testErrorPathSuite) TestLookup(c *C) {
+	store := testStore(c)
+	for _, fn := range []*logpattern_go_proto.FuncInfo{
+		testFunc("(*Server).Start", "dm/worker/server.go", 10, 30),
+		testFunc("(*Server).Stop", "dm/worker/server.go", 32, 40),
+		testFunc("(*Server).Start", "dm/master/server.go", 10, 30),
+		// the path of function is relative to GOPATH
+		testFunc("(*Relay).process", "github.com/pingcap/ticdc/dm/relay/relay.go", 50, 80),
+	} {
+		c.Assert(store.WriteFunction(context.Background(), fn), IsNil)
+	}
+	r, err := NewErrorPathRecorder(store)
+	c.Assert(err, IsNil)
+
+	cases := []struct {
+		file     string
+		line     int
+		expected string
+	}{
+		{"github.com/pingcap/ticdc/dm/worker/server.go", 12, "dm/worker/server.go:10"},
+		{"/root/go/src/github.com/pingcap/ticdc/dm/worker/server.go", 35, "dm/worker/server.go:32"},
+		{"github.com/pingcap/ticdc/dm/master/server.go", 30, "dm/master/server.go:10"},
+		// the module cache path with version
+		{"/root/go/pkg/mod/github.com/pingcap/ticdc@v1.2.3/dm/worker/server.go", 20, "dm/worker/server.go:10"},
+		{"/root/go/pkg/mod/github.com/pingcap/ticdc@v1.2.3/dm/relay/relay.go", 60, "github.com/pingcap/ticdc/dm/relay/relay.go:50"},
+		{"github.com/pingcap/ticdc@v1.2.3/dm/relay/relay.go", 60, "github.com/pingcap/ticdc/dm/relay/relay.go:50"},
+		{"github.com/pingcap/ticdc/dm/worker/server.go", 31, ""},
+		{"github.com/pingcap/ticdc/dm/relay/server.go", 12, ""},
+		{"github.com/pingcap/ticdc/dm/worker/worker.go", 12, ""},
+	}
+	for _, cs := range cases {
+		fn := r.lookup(&scanner.StackFrame{File: cs.file, Line: cs.line})
+		if cs.expected == "" {
+			c.Assert(fn, IsNil, Commentf("%s:%d", cs.file, cs.line))
+			continue
+		}
+		c.Assert(fn, NotNil, Commentf("%s:%d", cs.file, cs.line))
+		c.Assert(fn.Pos.FilePath+":"+fmt.Sprint(fn.Pos.LineNumber), Equals, cs.expected)
+	}
+}
+
+func (t *testErrorPathSuite) TestRecord(c *C) {
+	store := testStore(c)
+	start := testFunc("(*Server).Start", "dm/worker/server.go", 10, 30)
+	c.Assert(store.WriteFunction(context.Background(), start), IsNil)
+	r, err := NewErrorPathRecorder(store)
+	c.Assert(err, IsNil)
+
+	// the recursive frames cover the function once, the unknown frames are ignored
+	verbose := "[code=11011:class=functional:scope=internal:level=high], Message: no mysql source\n" +
+		"github.com/pingcap/ticdc/dm/worker.(*Server).Start\n\t/root/go/pkg/mod/github.com/pingcap/ticdc@v1.2.3/dm/worker/server.go:12\n" +
+		"github.com/pingcap/ticdc/dm/worker.(*Server).Start\n\t/root/go/pkg/mod/github.com/pingcap/ticdc@v1.2.3/dm/worker/server.go:20\n" +
+		"runtime.goexit\n\t/usr/local/go/src/runtime/asm_amd64.s:1371"
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Fields: map[string]string{scanner.ErrorVerboseKey: verbose}})
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Fields: map[string]string{scanner.ErrorVerboseKey: verbose}})
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Fields: map[string]string{"error": "no stack"}})
+	testFlush(c, store, r.Flush, r.Reset)
+
+	cov, err := store.GetErrorPathCoverage(context.Background(), start.Pos)
+	c.Assert(err, IsNil)
+	c.Assert(cov.CovCount, Equals, int64(2))
+	c.Assert(cov.CovCountByLog, DeepEquals, map[string]int64{"dm-worker.log": 2})
+}
@@ -1,17 +1,17 @@
 package recorder
 
 import (
-	"fmt"
-	"log"
+	"context"
 	"sync"
 
+	"github.com/IANTHEREAL/logutil/pkg/util"
 	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
 	scanner "github.com/IANTHEREAL/logutil/scanner/log_scan"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
 )
 
 // UnknowLogRecord used to record the log that exits in log files
-// but not captured by log extractor
-// Now support printing unknow logs through Flush method
+// but not captured by log extractor, they are flushed into store with their sample messages
 type UnknowLogRecord struct {
 	sync.RWMutex
 	logs map[string]*logpattern_go_proto.UnknowLogPattern
@@ -23,18 +23,25 @@ func NewUnknowLogRecorder() *UnknowLogRecord {
 	}
 }
 
+// Record records the log by its level and position, the logs without position are recorded by level and message
 func (r *UnknowLogRecord) Record(l *scanner.Log) {
+	unknow := &logpattern_go_proto.UnknowLogPattern{
+		Pos: &logpattern_go_proto.Position{
+			FilePath: l.Position,
+		},
+		Level:         l.Level,
+		CovCountByLog: make(map[string]int64),
+	}
+	if l.Position == "" {
+		unknow.Msg = l.Msg
+	}
+	id := util.UnknowLogToStr(unknow)
+
 	r.Lock()
-	log := r.logs[l.Position]
+	log := r.logs[id]
 	if log == nil {
-		log = &logpattern_go_proto.UnknowLogPattern{
-			Pos: &logpattern_go_proto.Position{
-				FilePath: l.Position,
-			},
-			Level:         l.Level,
-			CovCountByLog: make(map[string]int32),
-		}
-		r.logs[l.Position] = log
+		log = unknow
+		r.logs[id] = log
 	}
 
 	log.CovCount = log.CovCount + 1
@@ -43,27 +50,43 @@ func (r *UnknowLogRecord) Record(l *scanner.Log) {
 	} else {
 		log.CovCountByLog[l.LogPath] = count + 1
 	}
+	log.Samples = util.MergeUnknowLogSamples(log.Samples, l.Msg)
 	r.Unlock()
 }
 
-func (r *UnknowLogRecord) Flush() error {
+// Flush merges the unknow logs recorded since last reset into the unknow logs in store, the merged logs are written into batch
+func (r *UnknowLogRecord) Flush(batch *keyvalue.Store) error {
 	r.Lock()
 	defer r.Unlock()
 
-	if len(r.logs) == 0 {
-		return nil
-	}
-
-	result := fmt.Sprintf("total %d unknow logs.  exmaples:", len(r.logs))
-	index := 0
 	for _, log := range r.logs {
-		index++
-		result += fmt.Sprintf("[%s] [%s] [count=%d] [map %+v];", log.Pos, log.Level, log.CovCount, log.CovCountByLog)
-		if index >= 32 {
-			break
+		stored, err := batch.GetUnknowLog(context.Background(), log)
+		if err != nil {
+			return err
+		}
+		if stored != nil {
+			log = &logpattern_go_proto.UnknowLogPattern{
+				Pos:           log.Pos,
+				Level:         log.Level,
+				Msg:           log.Msg,
+				CovCount:      stored.CovCount + log.CovCount,
+				CovCountByLog: mergeCountByLog(stored.CovCountByLog, log.CovCountByLog),
+				Samples:       util.MergeUnknowLogSamples(stored.Samples, log.Samples...),
+			}
+		}
+
+		err = batch.WriteUnknowLog(context.Background(), log)
+		if err != nil {
+			return err
 		}
 	}
 
-	log.Printf("unknow logs  %s ...", result)
 	return nil
 }
+
+// Reset clears the unknow logs recorded, it's called after the flushed logs are committed
+func (r *UnknowLogRecord) Reset() {
+	r.Lock()
+	r.logs = make(map[string]*logpattern_go_proto.UnknowLogPattern)
+	r.Unlock()
+}
@@ -0,0 +1,60 @@
+package recorder
+
+import (
+	"context"
+
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	scanner "github.com/IANTHEREAL/logutil/scanner/log_scan"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testUnknowLogSuite{})
+
+type testUnknowLogSuite struct {
+}
+
+func (t *testUnknowLogSuite) TestRecord(c *C) {
+	store := testStore(c)
+	r := NewUnknowLogRecorder()
+	for _, msg := range []string{`"a"`, `"b"`, `"a"`} {
+		r.Record(&scanner.Log{LogPath: "dm-worker.log", Level: "error", Position: "main.go:12", Msg: msg})
+	}
+	// the logs at the same position are distinguished by level
+	r.Record(&scanner.Log{LogPath: "dm-master.log", Level: "warn", Position: "main.go:12", Msg: `"a"`})
+	// the logs without position are distinguished by message
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Level: "info", Msg: `"started"`})
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Level: "info", Msg: `"stopped"`})
+	testFlush(c, store, r.Flush, r.Reset)
+
+	// the logs are merged into the stored ones, and the distinct samples are capped
+	for _, msg := range []string{`"c"`, `"d"`} {
+		r.Record(&scanner.Log{LogPath: "dm-worker-2.log", Level: "error", Position: "main.go:12", Msg: msg})
+	}
+	r.Record(&scanner.Log{LogPath: "dm-worker.log", Level: "info", Msg: `"started"`})
+	testFlush(c, store, r.Flush, r.Reset)
+
+	get := func(level, position, msg string) *logpattern_go_proto.UnknowLogPattern {
+		unknow, err := store.GetUnknowLog(context.Background(), &logpattern_go_proto.UnknowLogPattern{
+			Pos: &logpattern_go_proto.Position{FilePath: position}, Level: level, Msg: msg,
+		})
+		c.Assert(err, IsNil)
+		c.Assert(unknow, NotNil)
+		return unknow
+	}
+	unknow := get("error", "main.go:12", "")
+	c.Assert(unknow.CovCount, Equals, int64(5))
+	c.Assert(unknow.CovCountByLog, DeepEquals, map[string]int64{"dm-worker.log": 3, "dm-worker-2.log": 2})
+	c.Assert(unknow.Samples, DeepEquals, []string{`"a"`, `"b"`, `"c"`})
+	unknow = get("warn", "main.go:12", "")
+	c.Assert(unknow.CovCount, Equals, int64(1))
+	c.Assert(unknow.CovCountByLog, DeepEquals, map[string]int64{"dm-master.log": 1})
+	c.Assert(get("info", "", `"started"`).CovCount, Equals, int64(2))
+	c.Assert(get("info", "", `"stopped"`).CovCount, Equals, int64(1))
+
+	count := 0
+	c.Assert(store.ScanUnknowLog(context.Background(), func(_, _ []byte) error {
+		count++
+		return nil
+	}), IsNil)
+	c.Assert(count, Equals, 4)
+}
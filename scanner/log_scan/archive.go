@@ -0,0 +1,189 @@
+package scanner
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// tarSuffixes are the file name suffixes of tar archives, the compressed archives are decompressed by magic bytes
+var tarSuffixes = []string{".tar", ".tar.gz", ".tgz", ".tar.bz2", ".tbz2", ".tar.zst", ".tzst"}
+
+// archiveIncludes are the globs of member names that are scanned in archives
+var archiveIncludes []string
+
+// RegisterArchiveInclude scans the members of archives matched by glob, all regular members are scanned
+// if there is no glob registered. The glob without slash matches the base name of member, e.g. *.log
+func RegisterArchiveInclude(glob string) {
+	archiveIncludes = append(archiveIncludes, glob)
+}
+
+// IsArchive reports whether the file is a tar or zip archive by its name
+func IsArchive(path string) bool {
+	name := strings.ToLower(path)
+	if strings.HasSuffix(name, ".zip") {
+		return true
+	}
+	for _, suffix := range tarSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// logSource returns the logs one by one, e.g. the members of archive, the log path is the member name.
+// It returns EOF if there are no more logs, and closes itself once any error is returned
+type logSource interface {
+	Next() (string, LogReader, error)
+}
+
+// NewArchiveLogScanner returns the scanner of the members of tar or zip archive, the members are read one by one
+// without being extracted, and the logs are attributed to the member names, e.g. dm-worker-1/log/dm-worker.log
+func NewArchiveLogScanner(archivePath string) (*LogScanner, error) {
+	var (
+		source logSource
+		err    error
+	)
+	if strings.HasSuffix(strings.ToLower(archivePath), ".zip") {
+		source, err = newZipSource(archivePath)
+	} else {
+		source, err = newTarSource(archivePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogScanner{
+		logPath: archivePath,
+		source:  source,
+	}, nil
+}
+
+// matchArchiveMember reports whether the member is scanned
+func matchArchiveMember(name string) bool {
+	if len(archiveIncludes) == 0 {
+		return true
+	}
+	for _, glob := range archiveIncludes {
+		path := name
+		if !strings.Contains(glob, "/") {
+			path = filepath.Base(name)
+		}
+		if ok, _ := filepath.Match(glob, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// tarSource reads the members of tar archive in order
+type tarSource struct {
+	archivePath string
+	fd          *os.File
+	reader      *tar.Reader
+	// closers close the decompressors of archive
+	closers []func() error
+	err     error
+}
+
+func newTarSource(archivePath string) (*tarSource, error) {
+	fd, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	content, closers, err := decompress(archivePath, fd)
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+
+	return &tarSource{
+		archivePath: archivePath,
+		fd:          fd,
+		reader:      tar.NewReader(content),
+		closers:     closers,
+	}, nil
+}
+
+func (t *tarSource) Next() (string, LogReader, error) {
+	if t.err != nil {
+		return "", nil, t.err
+	}
+	for {
+		header, err := t.reader.Next()
+		if err != nil {
+			t.err = err
+			t.close()
+			return "", nil, err
+		}
+		if !header.FileInfo().Mode().IsRegular() || !matchArchiveMember(header.Name) {
+			continue
+		}
+
+		reader, err := newLogReader(header.Name, t.reader)
+		if err != nil {
+			log.Printf("skip member %s of archive %s: %v", header.Name, t.archivePath, err)
+			continue
+		}
+		return header.Name, reader, nil
+	}
+}
+
+func (t *tarSource) close() error {
+	for _, closer := range t.closers {
+		closer()
+	}
+	return t.fd.Close()
+}
+
+// zipSource reads the members of zip archive in order
+type zipSource struct {
+	archivePath string
+	reader      *zip.ReadCloser
+	index       int
+	err         error
+}
+
+func newZipSource(archivePath string) (*zipSource, error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	return &zipSource{archivePath: archivePath, reader: reader}, nil
+}
+
+func (z *zipSource) Next() (string, LogReader, error) {
+	if z.err != nil {
+		return "", nil, z.err
+	}
+	for z.index < len(z.reader.File) {
+		file := z.reader.File[z.index]
+		z.index++
+		if !file.Mode().IsRegular() || !matchArchiveMember(file.Name) {
+			continue
+		}
+
+		member, err := file.Open()
+		if err != nil {
+			z.err = err
+			z.reader.Close()
+			return "", nil, err
+		}
+		reader, err := newLogReader(file.Name, member)
+		if err != nil {
+			member.Close()
+			log.Printf("skip member %s of archive %s: %v", file.Name, z.archivePath, err)
+			continue
+		}
+		reader.closers = append(reader.closers, member.Close)
+		return file.Name, reader, nil
+	}
+	z.err = io.EOF
+	z.reader.Close()
+	return "", nil, z.err
+}
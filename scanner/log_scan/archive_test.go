@@ -0,0 +1,110 @@
+package scanner
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testArchiveSuite{})
+
+type testArchiveSuite struct {
+}
+
+type testArchiveMember struct {
+	name    string
+	content []byte
+}
+
+func testArchiveMembers(c *C) []*testArchiveMember {
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, err := w.Write([]byte(`[2021/11/17 10:00:00.000 +00:00] [ERROR] [main.go:1] ["rotated"]` + "\n"))
+	c.Assert(err, IsNil)
+	c.Assert(w.Close(), IsNil)
+
+	return []*testArchiveMember{
+		{"dm-worker-1/log/dm-worker-2021-11-17T10-00-00.log.gz", gz.Bytes()},
+		{"dm-worker-1/log/dm-worker.log", []byte(`[2021/11/18 10:00:00.000 +00:00] [ERROR] [main.go:2] ["worker 1"]` + "\n")},
+		{"dm-worker-1/conf/dm-worker.toml", []byte("name = \"worker-1\"\n")},
+		{"dm-worker-2/log/dm-worker.log", []byte("2021/11/18 10:00:00 main.go:3: [error] worker 2\n")},
+	}
+}
+
+func testScanArchive(c *C, path string) map[string][]string {
+	ls, err := NewArchiveLogScanner(path)
+	c.Assert(err, IsNil)
+
+	logs := make(map[string][]string)
+	for {
+		l, err := ls.Scan()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, IsNil)
+		logs[l.LogPath] = append(logs[l.LogPath], l.Msg)
+	}
+	_, err = ls.Scan()
+	c.Assert(err, Equals, io.EOF)
+	return logs
+}
+
+func (t *testArchiveSuite) TestScanArchive(c *C) {
+	dir := c.MkDir()
+
+	tarPath := filepath.Join(dir, "logs.tar.gz")
+	fd, err := os.Create(tarPath)
+	c.Assert(err, IsNil)
+	gw := gzip.NewWriter(fd)
+	tw := tar.NewWriter(gw)
+	c.Assert(tw.WriteHeader(&tar.Header{Name: "dm-worker-1/", Typeflag: tar.TypeDir, Mode: 0o755}), IsNil)
+	for _, member := range testArchiveMembers(c) {
+		c.Assert(tw.WriteHeader(&tar.Header{Name: member.name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(member.content))}), IsNil)
+		_, err = tw.Write(member.content)
+		c.Assert(err, IsNil)
+	}
+	c.Assert(tw.Close(), IsNil)
+	c.Assert(gw.Close(), IsNil)
+	c.Assert(fd.Close(), IsNil)
+
+	zipPath := filepath.Join(dir, "logs.zip")
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, member := range testArchiveMembers(c) {
+		w, err := zw.Create(member.name)
+		c.Assert(err, IsNil)
+		_, err = w.Write(member.content)
+		c.Assert(err, IsNil)
+	}
+	c.Assert(zw.Close(), IsNil)
+	c.Assert(ioutil.WriteFile(zipPath, buf.Bytes(), 0o644), IsNil)
+
+	for _, path := range []string{tarPath, zipPath} {
+		c.Assert(IsArchive(path), IsTrue)
+		// the members that no parser is suitable for are skipped
+		c.Assert(testScanArchive(c, path), DeepEquals, map[string][]string{
+			"dm-worker-1/log/dm-worker-2021-11-17T10-00-00.log.gz": {`"rotated"`},
+			"dm-worker-1/log/dm-worker.log":                        {`"worker 1"`},
+			"dm-worker-2/log/dm-worker.log":                        {`"[error] worker 2"`},
+		})
+	}
+
+	RegisterArchiveInclude("dm-worker-2/*/*.log")
+	RegisterArchiveInclude("*.gz")
+	defer func() { archiveIncludes = nil }()
+	for _, path := range []string{tarPath, zipPath} {
+		c.Assert(testScanArchive(c, path), DeepEquals, map[string][]string{
+			"dm-worker-1/log/dm-worker-2021-11-17T10-00-00.log.gz": {`"rotated"`},
+			"dm-worker-2/log/dm-worker.log":                        {`"[error] worker 2"`},
+		})
+	}
+
+	c.Assert(IsArchive("dm-worker.log.gz"), IsFalse)
+}
@@ -0,0 +1,83 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"syscall"
+)
+
+// Checkpoint is the position that the log has been scanned to, the scanning of log can be resumed from it
+type Checkpoint struct {
+	// FilePath and FileID are the path and identity of the file being scanned, the file may be a rotated backup
+	// if the log is read with its backups
+	FilePath string
+	FileID   string
+	// Offset is the offset after the last line scanned in the decompressed content of file, the last line of file
+	// that isn't ended by newline yet may be still being written, so it isn't scanned and is read again after resuming
+	Offset int64
+}
+
+// ErrCheckpointLost is returned by resuming the rotated logs if the file of checkpoint is lost,
+// the coverage must be reset to scan the log again
+var ErrCheckpointLost = errors.New("file of checkpoint is lost")
+
+// checkpointReader is implemented by the readers that can be resumed from checkpoint
+type checkpointReader interface {
+	// checkpoint returns the checkpoint after the last line returned by Scan
+	checkpoint() Checkpoint
+	// resume skips the content before the checkpoint, it returns false if the checkpoint isn't of the file being read,
+	// e.g. the file is replaced or truncated, and the file is read from the beginning
+	resume(cp *Checkpoint) (bool, error)
+}
+
+// fileIdentity returns the identity of file that is kept after renaming, e.g. device and inode
+func fileIdentity(info os.FileInfo) string {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return fmt.Sprintf("%d:%d", stat.Dev, stat.Ino)
+	}
+	return ""
+}
+
+// Checkpoint returns the checkpoint after the last log returned by Scan, it returns nil if the log can't be resumed,
+// e.g. the log is read from archive or stdin
+func (l *LogScanner) Checkpoint() *Checkpoint {
+	if l.source != nil {
+		return nil
+	}
+	reader, ok := l.reader.(checkpointReader)
+	if !ok {
+		return nil
+	}
+	cp := reader.checkpoint()
+	if len(l.pending) > 0 {
+		cp = l.pending[0].before
+	}
+	if cp.FileID == "" {
+		return nil
+	}
+	return &cp
+}
+
+// Resume skips the logs before the checkpoint, it should be called before Scan. The log is scanned from the beginning
+// if the checkpoint doesn't match the log, e.g. the log file is replaced or truncated, but it returns ErrCheckpointLost
+// if the rotated backup of checkpoint is lost
+func (l *LogScanner) Resume(cp *Checkpoint) error {
+	reader, ok := l.reader.(checkpointReader)
+	if !ok || l.source != nil {
+		return nil
+	}
+
+	resumed, err := reader.resume(cp)
+	if err != nil {
+		return err
+	}
+	if !resumed {
+		log.Printf("checkpoint of log %s doesn't match file %s, scan it from the beginning", l.logPath, cp.FilePath)
+		return nil
+	}
+
+	log.Printf("resume log %s from offset %d of file %s", l.logPath, cp.Offset, cp.FilePath)
+	return nil
+}
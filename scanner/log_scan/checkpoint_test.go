@@ -0,0 +1,135 @@
+package scanner
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testCheckpointSuite{})
+
+type testCheckpointSuite struct {
+}
+
+func testZapLine(msg string) string {
+	return `[2021/11/17 10:00:00.000 +00:00] [ERROR] [main.go:1] ["` + msg + `"]`
+}
+
+// testScanFrom scans the log from the checkpoint, and returns the messages and the checkpoint after them
+func testScanFrom(c *C, path string, cp *Checkpoint) ([]string, *Checkpoint) {
+	ls, err := NewLogScanner(path)
+	c.Assert(err, IsNil)
+	if cp != nil {
+		c.Assert(ls.Resume(cp), IsNil)
+	}
+
+	var msgs []string
+	for {
+		l, err := ls.Scan()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, IsNil)
+		msgs = append(msgs, l.Msg)
+	}
+	return msgs, ls.Checkpoint()
+}
+
+func (t *testCheckpointSuite) TestResume(c *C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "dm-worker.log")
+	testAppendLog(c, path, testZapLine("1")+"\n"+testZapLine("2")+"\n")
+
+	msgs, cp := testScanFrom(c, path, nil)
+	c.Assert(msgs, DeepEquals, []string{`"1"`, `"2"`})
+	c.Assert(cp.FilePath, Equals, path)
+
+	// only the appended logs are scanned
+	msgs, cp = testScanFrom(c, path, cp)
+	c.Assert(msgs, IsNil)
+	testAppendLog(c, path, testZapLine("3")+"\n"+testZapLine("4"))
+	msgs, cp = testScanFrom(c, path, cp)
+	c.Assert(msgs, DeepEquals, []string{`"3"`})
+	size := int64(2*len(testZapLine("1")) + 2)
+	c.Assert(cp.Offset, Equals, size+int64(len(testZapLine("3"))+1))
+
+	// the line that isn't ended by newline may be still being written, it's scanned after it's ended
+	testAppendLog(c, path, "\n"+testZapLine("5")+"\n")
+	msgs, cp = testScanFrom(c, path, cp)
+	c.Assert(msgs, DeepEquals, []string{`"4"`, `"5"`})
+
+	// rotated by renaming and creating, the rest of backup is scanned before the new file
+	testAppendLog(c, path, testZapLine("6")+"\n")
+	c.Assert(os.Rename(path, filepath.Join(dir, "dm-worker.log.1")), IsNil)
+	testAppendLog(c, path, testZapLine("7")+"\n")
+	msgs, cp = testScanFrom(c, path, cp)
+	c.Assert(msgs, DeepEquals, []string{`"6"`, `"7"`})
+	c.Assert(cp.FilePath, Equals, path)
+
+	// truncated
+	c.Assert(ioutil.WriteFile(path, []byte(`[2021/11/17 10:00:00.000 +00:00] [ERROR] [a.go:1] ["8"]`+"\n"), 0o644), IsNil)
+	c.Assert(os.Remove(filepath.Join(dir, "dm-worker.log.1")), IsNil)
+	msgs, _ = testScanFrom(c, path, cp)
+	c.Assert(msgs, DeepEquals, []string{`"8"`})
+}
+
+func (t *testCheckpointSuite) TestResumeCompressed(c *C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "dm-worker.log.gz")
+	testWriteGzip(c, path, testZapLine("1")+"\n"+testZapLine("2")+"\n")
+
+	ls, err := NewLogScanner(path)
+	c.Assert(err, IsNil)
+	_, err = ls.Scan()
+	c.Assert(err, IsNil)
+	cp := ls.Checkpoint()
+	c.Assert(cp.Offset, Equals, int64(len(testZapLine("1"))+1))
+
+	msgs, _ := testScanFrom(c, path, cp)
+	c.Assert(msgs, DeepEquals, []string{`"2"`})
+}
+
+func (t *testCheckpointSuite) TestResumeLostBackup(c *C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "dm-worker.log")
+	testAppendLog(c, path, testZapLine("1")+"\n")
+	testAppendLog(c, filepath.Join(dir, "dm-worker.log.1"), testZapLine("0")+"\n")
+	msgs, cp := testScanFrom(c, path, nil)
+	c.Assert(msgs, DeepEquals, []string{`"0"`, `"1"`})
+
+	// the scanned file is rotated and compressed into a new file, the backups aren't scanned again
+	testAppendLog(c, path, testZapLine("2")+"\n")
+	testWriteGzip(c, filepath.Join(dir, "dm-worker.log.1.gz"), testZapLine("1")+"\n"+testZapLine("2")+"\n")
+	c.Assert(os.Rename(filepath.Join(dir, "dm-worker.log.1"), filepath.Join(dir, "dm-worker.log.2")), IsNil)
+	// the new log is created before removing the old one, so that the inode isn't reused
+	testAppendLog(c, path+".new", testZapLine("3")+"\n")
+	c.Assert(os.Rename(path+".new", path), IsNil)
+
+	ls, err := NewLogScanner(path)
+	c.Assert(err, IsNil)
+	err = ls.Resume(cp)
+	c.Assert(errors.Is(err, ErrCheckpointLost), IsTrue)
+	c.Assert(err, ErrorMatches, ".*dm-worker.log isn't found.*")
+}
+
+func (t *testCheckpointSuite) TestCheckpointWithPending(c *C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "dm-worker.log")
+	testAppendLog(c, path, testZapLine("1")+"\n"+testZapLine("2")+"\n")
+
+	// the lines read ahead to select parser are not included in the checkpoint
+	ls, err := NewLogScanner(path)
+	c.Assert(err, IsNil)
+	l, err := ls.Scan()
+	c.Assert(err, IsNil)
+	c.Assert(l.Msg, Equals, `"1"`)
+	msgs, _ := testScanFrom(c, path, ls.Checkpoint())
+	c.Assert(msgs, DeepEquals, []string{`"2"`})
+
+	// the archive members can't be resumed
+	c.Assert((&LogScanner{source: &zipSource{}}).Checkpoint(), IsNil)
+}
@@ -0,0 +1,131 @@
+package scanner
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/IANTHEREAL/logutil/pkg/util"
+)
+
+const (
+	// timeGroup is the regexp group name that captures log time
+	timeGroup = "time"
+	// levelGroup is the regexp group name that captures log level
+	levelGroup = "level"
+	// positionGroup is the regexp group name that captures the file name and line number, e.g. main.go:12
+	positionGroup = "position"
+	// msgGroup is the regexp group name that captures log message
+	msgGroup = "msg"
+)
+
+// ParserConfig is the config of user-defined log parsers, e.g.
+//
+//	[[parsers]]
+//	name = "log4j"
+//	files = ["connector-*.log"]
+//	grok = '''%{TIMESTAMP_ISO8601:time} %{LOGLEVEL:level} \[%{DATA:thread}\] %{NOTSPACE:position} - %{GREEDYDATA:msg}'''
+//	continuation = '''^(?:\s+at |Caused by:|\s+\.\.\. \d+ more)'''
+//	[parsers.levels]
+//	WARNING = "warn"
+//
+//	[[parsers]]
+//	name = "nginx-error"
+//	pattern = '''^(?P<time>\S+ \S+) \[(?P<level>\w+)\] (?P<msg>.*)$'''
+//
+//	[patterns]
+//	THREAD = '''[\w-]+'''
+type ParserConfig struct {
+	Parsers []*ParserRule `toml:"parsers"`
+	// Patterns are the custom grok patterns, they can be used in the grok of all parsers
+	Patterns map[string]string `toml:"patterns"`
+}
+
+// ParserRule describes a log parser that parses the log by regexp
+type ParserRule struct {
+	// Name is the name of parser in hub
+	Name string `toml:"name"`
+	// Files are the globs of log files that are parsed by the parser, the glob without slash matches file name,
+	// the parser can also be elected for other log files if their logs are suitable
+	Files []string `toml:"files"`
+	// Pattern is the regexp of log, it captures the log message in group msg, and optionally
+	// the log time, level and position in group time, level and position, other groups are kept as fields
+	Pattern string `toml:"pattern"`
+	// Grok is the grok pattern of log, e.g. %{LOGLEVEL:level} %{GREEDYDATA:msg}, it's used if pattern isn't set
+	Grok string `toml:"grok"`
+	// Continuation is the regexp of the lines that continue the previous log, e.g. the lines of stack trace,
+	// the pattern only matches the first line of log, the continuation lines are kept in the raw log
+	Continuation string `toml:"continuation"`
+	// Levels maps the captured level to the log level, e.g. warning => warn
+	Levels map[string]string `toml:"levels"`
+
+	pattern      *regexp.Regexp
+	continuation *regexp.Regexp
+}
+
+// LoadParserConfig reads the config file of user-defined log parsers
+func LoadParserConfig(path string) (*ParserConfig, error) {
+	cfg := &ParserConfig{}
+	if err := util.StrictDecodeFile(path, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, cfg.compile()
+}
+
+func (cfg *ParserConfig) compile() error {
+	names := make(map[string]struct{})
+	for i, rule := range cfg.Parsers {
+		if rule.Name == "" {
+			return fmt.Errorf("parser %d has no name", i)
+		}
+		if _, ok := names[rule.Name]; ok {
+			return fmt.Errorf("parser %s is defined more than once", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+
+		pattern := rule.Pattern
+		if pattern == "" && rule.Grok != "" {
+			var err error
+			pattern, err = compileGrok(rule.Grok, cfg.Patterns)
+			if err != nil {
+				return fmt.Errorf("parser %s has invalid grok: %v", rule.Name, err)
+			}
+		}
+		if pattern == "" {
+			return fmt.Errorf("parser %s has neither pattern nor grok", rule.Name)
+		}
+
+		var err error
+		rule.pattern, err = regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("parser %s has invalid pattern: %v", rule.Name, err)
+		}
+		if rule.pattern.SubexpIndex(msgGroup) < 0 {
+			return fmt.Errorf("parser %s pattern has no group %s", rule.Name, msgGroup)
+		}
+
+		if rule.Continuation != "" {
+			rule.continuation, err = regexp.Compile(rule.Continuation)
+			if err != nil {
+				return fmt.Errorf("parser %s has invalid continuation: %v", rule.Name, err)
+			}
+		}
+
+		for _, glob := range rule.Files {
+			if _, err := filepath.Match(glob, ""); err != nil {
+				return fmt.Errorf("parser %s has invalid file glob %s: %v", rule.Name, glob, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Register registers the parsers into hub, and the globs of log files that are parsed by them
+func (cfg *ParserConfig) Register() {
+	for _, rule := range cfg.Parsers {
+		RegisterLogParser(rule.Name, NewRegexLogParser(rule))
+		for _, glob := range rule.Files {
+			RegisterLogFileParser(glob, rule.Name)
+		}
+	}
+}
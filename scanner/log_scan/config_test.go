@@ -0,0 +1,150 @@
+package scanner
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testParserConfigSuite{})
+
+type testParserConfigSuite struct {
+}
+
+const testParserConfig = `
+[[parsers]]
+name = "log4j"
+files = ["connector-*.log"]
+grok = '''^%{TIMESTAMP_ISO8601:time} %{LOGLEVEL:level}\s+\[%{THREAD:thread}\] %{FILELINE:position} - %{GREEDYDATA:msg}'''
+continuation = '''^(?:\s+at |Caused by:)'''
+[parsers.levels]
+WARNING = "warn"
+ERROR = "error"
+
+[[parsers]]
+name = "nginx-error"
+pattern = '''^(?P<time>\S+ \S+) \[(?P<level>\w+)\] (?P<pid>\d+)#\d+: (?P<msg>.*)$'''
+continuation = '''^\s+'''
+
+[patterns]
+THREAD = '''[\w-]+'''
+`
+
+func (t *testParserConfigSuite) loadConfig(c *C, content string) (*ParserConfig, error) {
+	path := filepath.Join(c.MkDir(), "parser.toml")
+	c.Assert(ioutil.WriteFile(path, []byte(content), 0o644), IsNil)
+	return LoadParserConfig(path)
+}
+
+func (t *testParserConfigSuite) TestParse(c *C) {
+	cfg, err := t.loadConfig(c, testParserConfig)
+	c.Assert(err, IsNil)
+	c.Assert(cfg.Parsers, HasLen, 2)
+
+	parser := NewRegexLogParser(cfg.Parsers[0])
+	l, err := parser.Parse([]byte("2021-11-18 23:20:53,596 WARNING [sink-task-1] org/apache/kafka/WorkerSinkTask.java:612 - commit of offsets timed out\n\tat WorkerSinkTask.commit"))
+	c.Assert(err, IsNil)
+	c.Assert(l, DeepEquals, &Log{
+		Time:     "2021-11-18 23:20:53,596",
+		Level:    "warn",
+		Position: "WorkerSinkTask.java:612",
+		Msg:      `"commit of offsets timed out"`,
+		Fields:   map[string]string{"thread": "sink-task-1"},
+	})
+	c.Assert(parser.(MultiLineParser).IsContinuation([]byte("\tat WorkerSinkTask.commit")), IsTrue)
+	c.Assert(parser.(MultiLineParser).IsContinuation([]byte("2021-11-18 23:20:54,001 INFO [main] Main.java:1 - exit")), IsFalse)
+
+	parser = NewRegexLogParser(cfg.Parsers[1])
+	l, err = parser.Parse([]byte(`2021/11/18 23:20:53 [error] 7#7: *1 open() "/usr/share/nginx/html/favicon.ico" failed`))
+	c.Assert(err, IsNil)
+	c.Assert(l, DeepEquals, &Log{
+		Time:   "2021/11/18 23:20:53",
+		Level:  "error",
+		Msg:    `"*1 open() \"/usr/share/nginx/html/favicon.ico\" failed"`,
+		Fields: map[string]string{"pid": "7"},
+	})
+	// the pattern ends with $ only matches the first line of multi-line log
+	l, err = parser.Parse([]byte("2021/11/18 23:20:54 [warn] 7#7: upstream response is buffered\n\tupstream: \"http://127.0.0.1:8080\""))
+	c.Assert(err, IsNil)
+	c.Assert(l, DeepEquals, &Log{
+		Time:   "2021/11/18 23:20:54",
+		Level:  "warn",
+		Msg:    `"upstream response is buffered"`,
+		Fields: map[string]string{"pid": "7"},
+	})
+	_, err = parser.Parse([]byte("listening on 0.0.0.0"))
+	c.Assert(err, Equals, ErrNeedSkipLog)
+	c.Assert(parser.IsSuitable([]byte("listening on 0.0.0.0")), IsFalse)
+}
+
+func (t *testParserConfigSuite) TestInvalidConfig(c *C) {
+	for _, content := range []string{
+		"[[parsers]]\npattern = '(?P<msg>.*)'",
+		"[[parsers]]\nname = 'a'",
+		"[[parsers]]\nname = 'a'\npattern = '(?P<message>.*)'",
+		"[[parsers]]\nname = 'a'\npattern = '(?P<msg>.*'",
+		"[[parsers]]\nname = 'a'\ngrok = '%{UNKNOWN:msg}'",
+		"[[parsers]]\nname = 'a'\ngrok = '%{A:msg}'\n[patterns]\nA = '%{B}'\nB = '%{A}'",
+		"[[parsers]]\nname = 'a'\npattern = '(?P<msg>.*)'\ncontinuation = '['",
+		"[[parsers]]\nname = 'a'\npattern = '(?P<msg>.*)'\n[[parsers]]\nname = 'a'\npattern = '(?P<msg>.*)'",
+		"[[parsers]]\nname = 'a'\npattern = '(?P<msg>.*)'\nunknown = 1",
+	} {
+		_, err := t.loadConfig(c, content)
+		c.Assert(err, NotNil, Commentf("config %s", content))
+	}
+}
+
+func (t *testParserConfigSuite) TestScanMultiLineLog(c *C) {
+	cfg, err := t.loadConfig(c, testParserConfig)
+	c.Assert(err, IsNil)
+	defer func() {
+		fileParsers = nil
+		for _, rule := range cfg.Parsers {
+			delete(hub, rule.Name)
+		}
+	}()
+	cfg.Register()
+
+	ls := &LogScanner{
+		logPath: "/var/log/connector-1.log",
+		reader: newMockLogReader([]string{
+			"2021-11-18 23:20:53,596 ERROR [sink-task-1] WorkerSinkTask.java:612 - task is being killed",
+			"java.lang.NullPointerException: null",
+			"\tat WorkerSinkTask.commit(WorkerSinkTask.java:612)",
+			"Caused by: java.io.IOException",
+			"2021-11-18 23:20:54,001 WARNING [main] Main.java:12 - exit",
+		}),
+	}
+
+	// the first line isn't continued by the exception message, but by the stack trace
+	l, err := ls.Scan()
+	c.Assert(err, IsNil)
+	c.Assert(l.Msg, Equals, `"task is being killed"`)
+	c.Assert(l.LogPath, Equals, "/var/log/connector-1.log")
+
+	l, err = ls.Scan()
+	c.Assert(err, IsNil)
+	c.Assert(l.Position, Equals, "Main.java:12")
+	c.Assert(l.Level, Equals, "warn")
+
+	_, err = ls.Scan()
+	c.Assert(err, Equals, io.EOF)
+
+	// the continuation lines are joined with the first line
+	ls = &LogScanner{
+		logPath: "connector-2.log",
+		reader: newMockLogReader([]string{
+			"2021-11-18 23:20:53,596 ERROR [sink-task-1] WorkerSinkTask.java:612 - task is being killed",
+			"\tat WorkerSinkTask.commit(WorkerSinkTask.java:612)",
+			"Caused by: java.io.IOException",
+		}),
+	}
+	l, err = ls.Scan()
+	c.Assert(err, IsNil)
+	c.Assert(l.Msg, Equals, `"task is being killed"`)
+	c.Assert(ls.parser, Equals, hub["log4j"])
+	_, err = ls.Scan()
+	c.Assert(err, Equals, io.EOF)
+}
@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// envLoggerTargetKey is the field that keeps the target of env_logger log, which is the module path by default
+const envLoggerTargetKey = "target"
+
+// https://github.com/env-logger-rs/env_logger, the default log format of rust services, e.g.
+//
+//	[2021-08-17T09:05:41Z ERROR tikv::server] failed to connect 127.0.0.1:20160: timeout
+//
+// the timestamp and target may be disabled
+type envLoggerParser struct {
+}
+
+func newEnvLoggerParser() LogParser {
+	return &envLoggerParser{}
+}
+
+func (e *envLoggerParser) IsSuitable(content []byte) bool {
+	_, err := e.Parse(content)
+	return err == nil
+}
+
+func (e *envLoggerParser) Parse(content []byte) (*Log, error) {
+	if len(content) == 0 || content[0] != '[' {
+		return nil, ErrNeedSkipLog
+	}
+
+	pos := bytes.IndexByte(content, ']')
+	if pos < 0 {
+		return nil, ErrLogIncomplete
+	}
+
+	// header is [timestamp level target], the level is padded with spaces
+	header := strings.Fields(string(content[1:pos]))
+	level := -1
+	for i, field := range header {
+		if isVaildEnvLoggerLevel(field) {
+			level = i
+			break
+		}
+	}
+	if level < 0 || level > 1 || len(header) > level+2 {
+		return nil, ErrNeedSkipLog
+	}
+
+	l := &Log{
+		Level: header[level],
+		// the signatures of log patterns are quoted
+		Msg: strconv.Quote(strings.TrimPrefix(string(content[pos+1:]), " ")),
+	}
+	if level == 1 {
+		l.Time = header[0]
+	}
+	if len(header) == level+2 {
+		l.Fields = map[string]string{envLoggerTargetKey: header[level+1]}
+	}
+	return l, nil
+}
+
+func isVaildEnvLoggerLevel(level string) bool {
+	return level == "ERROR" || level == "WARN" || level == "INFO" || level == "DEBUG" || level == "TRACE"
+}
@@ -0,0 +1,205 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+// StdinLogPath is the log path that reads log from stdin
+const StdinLogPath = "-"
+
+// followLogReader reads the log file like tail -F, it waits for the new lines at the end of file,
+// and reads the file from the beginning if it's truncated, or reopens the file if it's rotated by renaming and creating.
+// It returns EOF after the context is done
+type followLogReader struct {
+	ctx      context.Context
+	logPath  string
+	interval time.Duration
+
+	fd     *os.File
+	fileID string
+	reader *bufio.Reader
+	offset int64
+	// partial is the last line that is not ended by newline yet
+	partial []byte
+	// cp is the checkpoint after the last line
+	cp Checkpoint
+}
+
+// NewFollowFileReader returns the reader that follows the log file, the file is checked every interval at the end of file
+func NewFollowFileReader(ctx context.Context, logPath string, interval time.Duration) (LogReader, error) {
+	fd, err := os.Open(logPath)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &followLogReader{
+		ctx:      ctx,
+		logPath:  logPath,
+		interval: interval,
+		fd:       fd,
+		reader:   bufio.NewReader(fd),
+	}
+	if info, err := fd.Stat(); err == nil {
+		f.fileID = fileIdentity(info)
+	}
+	f.cp = Checkpoint{FilePath: logPath, FileID: f.fileID}
+	return f, nil
+}
+
+func (f *followLogReader) Scan() ([]byte, error) {
+	for {
+		line, err := f.reader.ReadBytes('\n')
+		f.offset += int64(len(line))
+		if err == nil {
+			line = append(f.partial, line...)
+			f.partial = nil
+			f.cp = Checkpoint{FilePath: f.logPath, FileID: f.fileID, Offset: f.offset}
+			return bytes.TrimRight(line, "\r\n"), nil
+		} else if err != io.EOF {
+			return nil, err
+		}
+		f.partial = append(f.partial, line...)
+
+		select {
+		case <-f.ctx.Done():
+			// the partial line isn't scanned, the checkpoint is at its beginning to read it again after resuming
+			return nil, io.EOF
+		case <-time.After(f.interval):
+		}
+		if err := f.check(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// check reopens the log file if it's rotated, or reads it from the beginning if it's truncated
+func (f *followLogReader) check() error {
+	info, err := os.Stat(f.logPath)
+	if os.IsNotExist(err) {
+		// the log file is renamed, and the new one isn't created yet
+		return nil
+	} else if err != nil {
+		return err
+	}
+	current, err := f.fd.Stat()
+	if err != nil {
+		return err
+	}
+
+	if !os.SameFile(info, current) {
+		fd, err := os.Open(f.logPath)
+		if err != nil {
+			return err
+		}
+		log.Printf("log %s is rotated, read the new one", f.logPath)
+		f.fd.Close()
+		f.fd, f.fileID = fd, fileIdentity(info)
+	} else if info.Size() < f.offset {
+		if _, err := f.fd.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		log.Printf("log %s is truncated, read it from the beginning", f.logPath)
+	} else {
+		return nil
+	}
+
+	f.reader.Reset(f.fd)
+	f.offset, f.partial = 0, nil
+	return nil
+}
+
+func (f *followLogReader) checkpoint() Checkpoint {
+	return f.cp
+}
+
+// resume seeks to the offset of checkpoint if the checkpoint is of the file and the file isn't truncated
+func (f *followLogReader) resume(cp *Checkpoint) (bool, error) {
+	if f.fileID == "" || cp.FileID != f.fileID {
+		return false, nil
+	}
+	info, err := f.fd.Stat()
+	if err != nil {
+		return false, err
+	}
+	if info.Size() < cp.Offset {
+		return false, nil
+	}
+	if _, err := f.fd.Seek(cp.Offset, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	f.reader.Reset(f.fd)
+	f.offset, f.partial = cp.Offset, nil
+	f.cp = Checkpoint{FilePath: f.logPath, FileID: f.fileID, Offset: cp.Offset}
+	return true, nil
+}
+
+// Ready reports whether there is content that can be read without waiting
+func (f *followLogReader) Ready() bool {
+	info, err := f.fd.Stat()
+	return err == nil && info.Size() > f.offset
+}
+
+func (f *followLogReader) Close() error {
+	return f.fd.Close()
+}
+
+// NewStdinReader returns the reader of stdin, stdin is closed after the context is done,
+// so that the blocked reading returns
+func NewStdinReader(ctx context.Context) (LogReader, error) {
+	reader, err := newLogReader(StdinLogPath, os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		os.Stdin.Close()
+	}()
+	return &stdinLogReader{ctx: ctx, reader: reader}, nil
+}
+
+type stdinLogReader struct {
+	ctx    context.Context
+	reader LogReader
+}
+
+func (s *stdinLogReader) Scan() ([]byte, error) {
+	line, err := s.reader.Scan()
+	if err != nil && s.ctx.Err() != nil {
+		return nil, io.EOF
+	}
+	return line, err
+}
+
+func (s *stdinLogReader) Close() error {
+	return s.reader.Close()
+}
+
+// NewFollowLogScanner returns the scanner that follows the log file until the context is done,
+// the log is read from stdin if the log path is StdinLogPath
+func NewFollowLogScanner(ctx context.Context, logPath string, interval time.Duration) (*LogScanner, error) {
+	var (
+		reader LogReader
+		err    error
+	)
+	if logPath == StdinLogPath {
+		reader, err = NewStdinReader(ctx)
+	} else {
+		reader, err = NewFollowFileReader(ctx, logPath, interval)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogScanner{
+		logPath: logPath,
+		reader:  reader,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package scanner
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testFollowSuite{})
+
+type testFollowSuite struct {
+}
+
+func testAppendLog(c *C, path, content string) {
+	fd, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	c.Assert(err, IsNil)
+	_, err = fd.WriteString(content)
+	c.Assert(err, IsNil)
+	c.Assert(fd.Close(), IsNil)
+}
+
+func testScanLine(c *C, reader LogReader) string {
+	line, err := reader.Scan()
+	c.Assert(err, IsNil)
+	return string(line)
+}
+
+func (t *testFollowSuite) TestFollowFileReader(c *C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "dm-worker.log")
+	testAppendLog(c, path, "line 1\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reader, err := NewFollowFileReader(ctx, path, 10*time.Millisecond)
+	c.Assert(err, IsNil)
+	c.Assert(testScanLine(c, reader), Equals, "line 1")
+	c.Assert(reader.(waitingReader).Ready(), IsFalse)
+
+	// the line is returned after it's ended by newline
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		testAppendLog(c, path, "line ")
+		time.Sleep(30 * time.Millisecond)
+		testAppendLog(c, path, "2\n")
+	}()
+	c.Assert(testScanLine(c, reader), Equals, "line 2")
+
+	// truncated
+	c.Assert(ioutil.WriteFile(path, []byte("new 1\n"), 0o644), IsNil)
+	c.Assert(testScanLine(c, reader), Equals, "new 1")
+
+	// rotated by renaming and creating
+	c.Assert(os.Rename(path, path+".1"), IsNil)
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		testAppendLog(c, path, "rotated 1\n")
+	}()
+	c.Assert(testScanLine(c, reader), Equals, "rotated 1")
+
+	// the partial line isn't returned after the context is done, the checkpoint is at its beginning
+	testAppendLog(c, path, "partial")
+	cancel()
+	_, err = reader.Scan()
+	c.Assert(err, Equals, io.EOF)
+	cp := reader.(checkpointReader).checkpoint()
+	c.Assert(cp.Offset, Equals, int64(len("rotated 1\n")))
+	c.Assert(reader.Close(), IsNil)
+}
+
+func (t *testFollowSuite) TestFollowLogScanner(c *C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "dm-worker.log")
+	testAppendLog(c, path, `[2021/11/17 10:00:00.000 +00:00] [ERROR] [main.go:1] ["first"]`+"\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ls, err := NewFollowLogScanner(ctx, path, 10*time.Millisecond)
+	c.Assert(err, IsNil)
+
+	// the parser is selected by the written lines without waiting for more samples
+	l, err := ls.Scan()
+	c.Assert(err, IsNil)
+	c.Assert(l.Msg, Equals, `"first"`)
+
+	testAppendLog(c, path, `[2021/11/17 10:00:01.000 +00:00] [WARN] [main.go:2] ["second"]`+"\n")
+	l, err = ls.Scan()
+	c.Assert(err, IsNil)
+	c.Assert(l.Msg, Equals, `"second"`)
+
+	cancel()
+	_, err = ls.Scan()
+	c.Assert(err, Equals, io.EOF)
+}
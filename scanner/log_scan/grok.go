@@ -0,0 +1,80 @@
+package scanner
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// grokMaxDepth limits the nesting of grok patterns, which prevents the recursive patterns
+const grokMaxDepth = 16
+
+// grokRegexp matches the grok syntax %{PATTERN} and %{PATTERN:field}, the optional type, e.g. %{INT:retry:int}, is ignored
+var grokRegexp = regexp.MustCompile(`%\{(\w+)(?::(\w+))?(?::\w+)?\}`)
+
+// grokPatterns are the common patterns of logstash grok
+var grokPatterns = map[string]string{
+	"WORD":              `\b\w+\b`,
+	"NOTSPACE":          `\S+`,
+	"SPACE":             `\s*`,
+	"DATA":              `.*?`,
+	"GREEDYDATA":        `.*`,
+	"INT":               `[+-]?\d+`,
+	"NUMBER":            `[+-]?(?:\d+(?:\.\d*)?|\.\d+)`,
+	"QUOTEDSTRING":      `"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`,
+	"LOGLEVEL":          `(?i:trace|debug|info|notice|warn|warning|error|err|crit|critical|fatal|severe|emerg|alert)`,
+	"YEAR":              `\d{4}`,
+	"MONTHNUM":          `0?[1-9]|1[0-2]`,
+	"MONTHDAY":          `0?[1-9]|[12]\d|3[01]`,
+	"HOUR":              `[01]?\d|2[0-3]`,
+	"MINUTE":            `[0-5]\d`,
+	"SECOND":            `[0-5]?\d(?:[.,]\d+)?|60`,
+	"ISO8601_TIMEZONE":  `Z|[+-]%{HOUR}(?::?%{MINUTE})`,
+	"TIME":              `%{HOUR}:%{MINUTE}(?::%{SECOND})?`,
+	"DATE":              `%{YEAR}[/-]%{MONTHNUM}[/-]%{MONTHDAY}`,
+	"TIMESTAMP_ISO8601": `%{YEAR}-%{MONTHNUM}-%{MONTHDAY}[T ]%{HOUR}:?%{MINUTE}(?::?%{SECOND})?%{ISO8601_TIMEZONE}?`,
+	"PATH":              `(?:/[^/\s]*)+|(?:[A-Za-z]:)?(?:\\[^\\\s]*)+`,
+	"FILELINE":          `[\w./-]+:\d+`,
+}
+
+// compileGrok expands the grok patterns into regexp, the patterns in custom take precedence over the common patterns,
+// e.g. %{TIMESTAMP_ISO8601:time} %{LOGLEVEL:level} %{GREEDYDATA:msg} is expanded into a regexp with group time, level and msg
+func compileGrok(pattern string, custom map[string]string) (string, error) {
+	return expandGrok(pattern, custom, 0)
+}
+
+func expandGrok(pattern string, custom map[string]string, depth int) (string, error) {
+	if depth > grokMaxDepth {
+		return "", fmt.Errorf("grok patterns are nested too deep, there may be recursive patterns")
+	}
+
+	var (
+		b    strings.Builder
+		last int
+	)
+	for _, m := range grokRegexp.FindAllStringSubmatchIndex(pattern, -1) {
+		b.WriteString(pattern[last:m[0]])
+		last = m[1]
+
+		name := pattern[m[2]:m[3]]
+		def, ok := custom[name]
+		if !ok {
+			def, ok = grokPatterns[name]
+		}
+		if !ok {
+			return "", fmt.Errorf("unknown grok pattern %s", name)
+		}
+		expanded, err := expandGrok(def, custom, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		if m[4] >= 0 {
+			fmt.Fprintf(&b, "(?P<%s>%s)", pattern[m[4]:m[5]], expanded)
+		} else {
+			fmt.Fprintf(&b, "(?:%s)", expanded)
+		}
+	}
+	b.WriteString(pattern[last:])
+	return b.String(), nil
+}
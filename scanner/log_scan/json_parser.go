@@ -0,0 +1,114 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+)
+
+// JSONLogKeys are the keys of the log level, time, caller and message in JSON log
+type JSONLogKeys struct {
+	Level  string
+	Time   string
+	Caller string
+	Msg    string
+}
+
+// DefaultJSONLogKeys are the keys used by the production JSON encoder of zap
+var DefaultJSONLogKeys = JSONLogKeys{
+	Level:  "level",
+	Time:   "ts",
+	Caller: "caller",
+	Msg:    "msg",
+}
+
+// jsonLogParser parses the log that is printed as one JSON object per line, e.g.
+//
+//	{"level":"error","ts":1637277716.901,"caller":"worker/source_worker.go:605","msg":"failed to update source status","error":"..."}
+//
+// the keys except level, time, caller and message are kept as fields,
+// the lines that are not JSON objects, e.g. the panic output, are skipped
+type jsonLogParser struct {
+	keys JSONLogKeys
+}
+
+// NewJSONLogParser returns a JSON log parser, the empty keys are set by DefaultJSONLogKeys
+func NewJSONLogParser(keys JSONLogKeys) LogParser {
+	if keys.Level == "" {
+		keys.Level = DefaultJSONLogKeys.Level
+	}
+	if keys.Time == "" {
+		keys.Time = DefaultJSONLogKeys.Time
+	}
+	if keys.Caller == "" {
+		keys.Caller = DefaultJSONLogKeys.Caller
+	}
+	if keys.Msg == "" {
+		keys.Msg = DefaultJSONLogKeys.Msg
+	}
+	return &jsonLogParser{keys: keys}
+}
+
+func (j *jsonLogParser) IsSuitable(content []byte) bool {
+	_, err := j.Parse(content)
+	return err == nil
+}
+
+func (j *jsonLogParser) Parse(content []byte) (*Log, error) {
+	content = bytes.TrimSpace(content)
+	if len(content) == 0 || content[0] != '{' {
+		return nil, ErrNeedSkipLog
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(content, &object); err != nil {
+		return nil, ErrNeedSkipLog
+	}
+
+	level, ok := jsonString(object[j.keys.Level])
+	if !ok || !isVaildLogEvel(level) {
+		return nil, ErrNeedSkipLog
+	}
+	msg, ok := jsonString(object[j.keys.Msg])
+	if !ok {
+		return nil, ErrNeedSkipLog
+	}
+
+	l := &Log{
+		Level: level,
+		// the signatures of log patterns are quoted
+		Msg: strconv.Quote(msg),
+	}
+	l.Time, _ = jsonString(object[j.keys.Time])
+	if caller, ok := jsonString(object[j.keys.Caller]); ok {
+		// log patterns are matched by the file name and line number, e.g. source_worker.go:605
+		l.Position = filepath.Base(caller)
+	}
+
+	for key, value := range object {
+		if key == j.keys.Level || key == j.keys.Time || key == j.keys.Caller || key == j.keys.Msg {
+			continue
+		}
+		if l.Fields == nil {
+			l.Fields = make(map[string]string)
+		}
+		l.Fields[key], _ = jsonString(value)
+	}
+	return l, nil
+}
+
+// jsonString returns the string value of JSON string, or the raw text of other JSON values, e.g. numbers
+func jsonString(value json.RawMessage) (string, bool) {
+	if len(value) == 0 {
+		return "", false
+	}
+	if value[0] == '"' {
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return "", false
+		}
+		return s, true
+	}
+	return string(value), true
+}
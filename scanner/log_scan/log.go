@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"sort"
 )
 
 // Log is a structured representation of a line of log in the log file
@@ -13,26 +14,71 @@ type Log struct {
 	Level    string
 	Position string
 	Msg      string
+
+	// Fields are the structured key/value pairs after the log message, e.g. [error="..."]
+	Fields map[string]string
+
+	// Raw is the log line, it includes the continuation lines of multi-line log
+	Raw string
 }
 
 func (l *Log) String() string {
 	return fmt.Sprintf("%s %s %s %s %s", l.LogPath, l.Position, l.Time, l.Level, l.Msg)
 }
 
+// selectSampleLines is the number of lines sampled to select log parser
+const selectSampleLines = 32
+
+// waitingReader is implemented by the readers that wait for new lines, e.g. following the log file,
+// Ready reports whether the next line can be read without waiting
+type waitingReader interface {
+	Ready() bool
+}
+
+// pendingLine is the line read ahead, before is the checkpoint before the line
+type pendingLine struct {
+	content []byte
+	before  Checkpoint
+}
+
 // LogScanner used to read and parse log from log files one line by one line
 type LogScanner struct {
 	logPath string
 
 	reader LogReader
 	parser LogParser
+	// parserName is the name of parser in hub
+	parserName string
+
+	// pending are the lines read ahead while sampling lines or looking for the continuation lines
+	pending []pendingLine
+	// unswitched is the number of sampled lines after the line that failed to switch parser, the parser isn't
+	// switched on them again until a line is parsed, so the skipped lines aren't sampled and scored one by one
+	unswitched int
+
+	// source is not nil if the logs are read from archive, the log path and reader are changed to the next log
+	// after the current log is scanned
+	source logSource
 }
 
+// NewLogScanner returns the scanner of log file, the rotated backups of log file are read before it as one log
 func NewLogScanner(logPath string) (*LogScanner, error) {
-	reader, err := NewFileReader(logPath)
+	files, err := RotatedLogFiles(logPath)
 	if err != nil {
 		return nil, err
 	}
 
+	var reader LogReader
+	if len(files) == 1 {
+		reader, err = NewFileReader(files[0])
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		log.Printf("read rotated logs %v as log %s", files, logPath)
+		reader = NewRotatedFileReader(files)
+	}
+
 	return &LogScanner{
 		logPath: logPath,
 		reader:  reader,
@@ -42,22 +88,72 @@ func NewLogScanner(logPath string) (*LogScanner, error) {
 // Scan return one line log
 // If there are no more logs, it will regret EOF
 func (l *LogScanner) Scan() (*Log, error) {
-	line, err := l.reader.Scan()
+	if l.source == nil {
+		return l.scan()
+	}
+
+	for {
+		if l.reader != nil {
+			lg, err := l.scan()
+			if err == nil {
+				return lg, nil
+			} else if err == ErrNotFoundParser {
+				log.Printf("skip log %s that no parser is suitable for", l.logPath)
+			} else if err != io.EOF {
+				return nil, err
+			}
+			l.reader.Close()
+		}
+
+		if err := l.nextSource(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// nextSource switches to the next log of source, the parser is selected again for it
+func (l *LogScanner) nextSource() error {
+	logPath, reader, err := l.source.Next()
+	if err != nil {
+		l.reader = nil
+		return err
+	}
+
+	l.logPath, l.reader = logPath, reader
+	l.parser, l.parserName, l.pending, l.unswitched = nil, "", nil, 0
+	return nil
+}
+
+func (l *LogScanner) scan() (*Log, error) {
+	line, err := l.nextLine()
 	if err != nil {
 		return nil, err
 	}
 
 	if l.parser == nil {
+		// the reader may reuse the buffer of line while sampling lines
+		line = append([]byte{}, line...)
 		if err := l.selectLogParser(line); err != nil {
 			return nil, err
 		}
 	}
+	if parser, ok := l.parser.(MultiLineParser); ok {
+		if line, err = l.scanContinuation(parser, line); err != nil {
+			return nil, err
+		}
+	}
 
 	lg, err := l.parser.Parse(line)
 	for err != nil {
 		if err == io.EOF {
 			return nil, err
 		} else if err == ErrNeedSkipLog {
+			// the format of log may be changed, e.g. the process is restarted with another log config
+			line = append([]byte{}, line...)
+			if l.switchLogParser(line) {
+				lg, err = l.parser.Parse(line)
+				continue
+			}
 			// skip line
 			log.Printf("[skip log] [log file %s] [log data %s]", l.logPath, line)
 			line = []byte{}
@@ -70,26 +166,211 @@ func (l *LogScanner) Scan() (*Log, error) {
 		lg, line, err = l.scanLog(line)
 	}
 
+	l.unswitched = 0
 	lg.LogPath = l.logPath
+	lg.Raw = string(line)
 	return lg, nil
 }
 
-// selectLogParser try to find the suitable log parser
+// selectLogParser try to find the suitable log parser, the parser forced or registered for the log file is used first,
+// otherwise the parser that parses most of the sampled lines is elected
 func (l *LogScanner) selectLogParser(content []byte) error {
+	if name, parser, ok := selectFileParser(l.logPath); ok {
+		log.Printf("select parser %s for log %s", name, l.logPath)
+		l.parser, l.parserName = parser, name
+
+		return nil
+	}
+
+	sample, err := l.sampleLines(content)
+	if err != nil {
+		return err
+	}
+	name, _ := electLogParser(sample)
+	if name == "" {
+		return ErrNotFoundParser
+	}
+
+	log.Printf("elect parser %s for log %s", name, l.logPath)
+	l.parser, l.parserName = hub[name], name
+	return nil
+}
+
+// switchLogParser switches to the parser that parses the line and the following lines better than current parser,
+// the forced parser is never switched
+func (l *LogScanner) switchLogParser(line []byte) bool {
+	if _, ok := forcedParsers[l.logPath]; ok {
+		return false
+	}
+	if l.unswitched > 0 {
+		l.unswitched--
+		return false
+	}
+
+	suitable := false
 	for name, parser := range hub {
-		if parser.IsSuitable(content) {
-			log.Printf("elect parser %s for log %s", name, l.logPath)
-			l.parser = parser
+		if name == l.parserName {
+			continue
+		}
+		if _, err := parser.Parse(line); err == nil {
+			suitable = true
+			break
+		}
+	}
+	if !suitable {
+		return false
+	}
+
+	sample, err := l.sampleLines(line)
+	if err != nil {
+		return false
+	}
+	name, score := electLogParser(sample)
+	if name == "" || name == l.parserName || !score.better(scoreLogParser(l.parser, sample)) {
+		l.unswitched = len(sample) - 1
+		return false
+	}
+
+	log.Printf("switch parser from %s to %s for log %s", l.parserName, name, l.logPath)
+	l.parser, l.parserName = hub[name], name
+	return true
+}
+
+// parserScore is the score of parser on the sampled lines, the lines parsed with time, level or position count first,
+// which tells the parser of the format from the one that takes any line as message
+type parserScore struct {
+	// structured is the number of lines parsed with time, level or position
+	structured int
+	// parsed is the number of lines parsed
+	parsed int
+	// fields is the number of time, level and position parsed
+	fields int
+}
+
+func (s parserScore) better(other parserScore) bool {
+	if s.structured != other.structured {
+		return s.structured > other.structured
+	}
+	if s.parsed != other.parsed {
+		return s.parsed > other.parsed
+	}
+	return s.fields > other.fields
+}
+
+func scoreLogParser(parser LogParser, sample [][]byte) parserScore {
+	var score parserScore
+	for _, line := range sample {
+		lg, err := parser.Parse(line)
+		if err != nil {
+			continue
+		}
+		score.parsed++
+
+		fields := 0
+		for _, field := range []string{lg.Time, lg.Level, lg.Position} {
+			if field != "" {
+				fields++
+			}
+		}
+		if fields > 0 {
+			score.structured++
+		}
+		score.fields += fields
+	}
+	return score
+}
+
+// electLogParser returns the parser that has the best score on the sampled lines, the parsers of same score are
+// elected by name, so that the election is deterministic. It returns empty name if no line is parsed
+func electLogParser(sample [][]byte) (string, parserScore) {
+	names := make([]string, 0, len(hub))
+	for name := range hub {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		elected string
+		best    parserScore
+	)
+	for _, name := range names {
+		if score := scoreLogParser(hub[name], sample); score.better(best) {
+			elected, best = name, score
+		}
+	}
+	return elected, best
+}
+
+// sampleLines returns the line and the lines after it, the lines are read ahead and kept for scanning,
+// so line should not be the buffer of reader
+func (l *LogScanner) sampleLines(line []byte) ([][]byte, error) {
+	for len(l.pending) < selectSampleLines-1 {
+		if r, ok := l.reader.(waitingReader); ok && !r.Ready() {
+			break
+		}
+		before := l.Checkpoint()
+		next, err := l.reader.Scan()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		// the reader may reuse the buffer of line
+		pending := pendingLine{content: append([]byte{}, next...)}
+		if before != nil {
+			pending.before = *before
+		}
+		l.pending = append(l.pending, pending)
+	}
 
-			return nil
+	sample := [][]byte{line}
+	for _, pending := range l.pending {
+		if len(sample) == selectSampleLines {
+			break
 		}
+		sample = append(sample, pending.content)
+	}
+	return sample, nil
+}
+
+// nextLine returns the line read ahead, or the next line of reader
+func (l *LogScanner) nextLine() ([]byte, error) {
+	if len(l.pending) > 0 {
+		line := l.pending[0].content
+		l.pending = l.pending[1:]
+		return line, nil
 	}
+	return l.reader.Scan()
+}
 
-	return ErrNotFoundParser
+// scanContinuation appends the continuation lines to the first line of log,
+// the first line that doesn't continue the log is kept for the next log
+func (l *LogScanner) scanContinuation(parser MultiLineParser, line []byte) ([]byte, error) {
+	// the reader may reuse the buffer of line
+	line = append([]byte{}, line...)
+	for {
+		before := l.Checkpoint()
+		next, err := l.nextLine()
+		if err == io.EOF {
+			return line, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if !parser.IsContinuation(next) {
+			pending := pendingLine{content: append([]byte{}, next...)}
+			if before != nil {
+				pending.before = *before
+			}
+			l.pending = append([]pendingLine{pending}, l.pending...)
+			return line, nil
+		}
+		line = append(append(line, '\n'), next...)
+	}
 }
 
 func (l *LogScanner) scanLog(content []byte) (*Log, []byte, error) {
-	rest, err := l.reader.Scan()
+	rest, err := l.nextLine()
 	if err != nil {
 		return nil, content, err
 	}
@@ -1,7 +1,11 @@
 package scanner
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"regexp"
+	"time"
 
 	. "github.com/pingcap/check"
 )
@@ -17,9 +21,11 @@ func (t *testLogSuite) TestRead(c *C) {
 		reader: newMockLogReader(logs),
 	}
 
-	for _, lg := range lgs {
+	for i, lg := range lgs {
 		slg, err := ls.Scan()
 		c.Assert(err, IsNil)
+		c.Assert(slg.Raw, Equals, logs[i])
+		slg.Raw = ""
 		c.Assert(slg, DeepEquals, lg)
 	}
 
@@ -72,3 +78,120 @@ func newMockLogReader(logs []string) LogReader {
 		logs: logs,
 	}
 }
+
+func (t *testLogSuite) TestSelectParser(c *C) {
+	logs, lgs := testGenerateStandardZapLogs()
+	// the banner isn't parsed by any parser, and the zap logs may be parsed by user-defined parsers that take whole line
+	logs = append([]string{"Welcome to DM", "logfmt=1"}, logs...)
+	RegisterLogParser("any", NewRegexLogParser(&ParserRule{pattern: regexp.MustCompile(`(?P<msg>.+)`)}))
+	defer delete(hub, "any")
+
+	for i := 0; i < 10; i++ {
+		ls := &LogScanner{reader: newMockLogReader(logs)}
+		slg, err := ls.Scan()
+		c.Assert(err, IsNil)
+		c.Assert(ls.parserName, Equals, "zap")
+		slg.Raw = ""
+		c.Assert(slg, DeepEquals, lgs[0])
+	}
+
+	// the format is changed by restarting with another log config
+	logs = []string{
+		"2021/11/18 23:20:53 main.go:12: [error] start failed",
+		"2021/11/18 23:20:53 main.go:13: [error] exit",
+		`ts=2021-11-18T23:21:53Z level=error caller=main.go:12 msg="start failed"`,
+		`ts=2021-11-18T23:21:53Z level=info caller=main.go:20 msg=started`,
+	}
+	ls := &LogScanner{reader: newMockLogReader(logs)}
+	for _, expected := range []string{"log", "log", "logfmt", "logfmt"} {
+		slg, err := ls.Scan()
+		c.Assert(err, IsNil)
+		c.Assert(ls.parserName, Equals, expected)
+		c.Assert(slg.Position, Matches, `main\.go:\d+`)
+	}
+	_, err := ls.Scan()
+	c.Assert(err, Equals, io.EOF)
+
+	// the forced parser is never switched
+	c.Assert(ForceLogParser("dm-worker.log", "unknown"), Equals, ErrNotFoundParser)
+	c.Assert(ForceLogParser("dm-worker.log", "log"), IsNil)
+	defer delete(forcedParsers, "dm-worker.log")
+	ls = &LogScanner{logPath: "dm-worker.log", reader: newMockLogReader(logs)}
+	for i := 0; i < 2; i++ {
+		slg, err := ls.Scan()
+		c.Assert(err, IsNil)
+		c.Assert(ls.parserName, Equals, "log")
+		c.Assert(slg.Level, Equals, "error")
+	}
+	_, err = ls.Scan()
+	c.Assert(err, Equals, io.EOF)
+}
+
+// testJunkParser parses the junk lines without time, level and position, and counts the parsed lines
+type testJunkParser struct {
+	calls int
+}
+
+func (p *testJunkParser) Parse(content []byte) (*Log, error) {
+	p.calls++
+	if !bytes.HasPrefix(content, []byte("junk")) {
+		return nil, ErrNeedSkipLog
+	}
+	return &Log{Msg: string(content)}, nil
+}
+
+func (p *testJunkParser) IsSuitable(content []byte) bool {
+	return bytes.HasPrefix(content, []byte("junk"))
+}
+
+func (t *testLogSuite) TestSwitchParserOnce(c *C) {
+	junk := &testJunkParser{}
+	RegisterLogParser("junk", junk)
+	defer delete(hub, "junk")
+
+	logs, lgs := testGenerateStandardZapLogs()
+	for i := 0; i < 3; i++ {
+		logs = append([]string{fmt.Sprintf("junk %d", i)}, logs...)
+	}
+	ls := &LogScanner{reader: newMockLogReader(logs), parser: hub["zap"], parserName: "zap"}
+	for _, lg := range lgs {
+		slg, err := ls.Scan()
+		c.Assert(err, IsNil)
+		c.Assert(ls.parserName, Equals, "zap")
+		slg.Raw = ""
+		c.Assert(slg, DeepEquals, lg)
+	}
+	_, err := ls.Scan()
+	c.Assert(err, Equals, io.EOF)
+	// the parser isn't switched to junk parser, and the lines are sampled and scored once for the skipped lines
+	c.Assert(junk.calls, Equals, 1+len(logs))
+}
+
+func (t *testLogSuite) TestParseTime(c *C) {
+	local := time.Local
+	defer func() {
+		time.Local = local
+	}()
+	time.Local = time.FixedZone("UTC+8", 8*60*60)
+
+	expected := time.Date(2021, 11, 18, 23, 20, 53, 596000000, time.UTC)
+	localExpected := time.Date(2021, 11, 18, 23, 20, 53, 596000000, time.Local)
+	for s, expected := range map[string]time.Time{
+		"2021/11/18 23:20:53.596 +00:00": expected,
+		"2021-11-18T23:20:53.596Z":       expected,
+		"1637277653.596":                 expected,
+		// the time without zone is local time
+		"2021/11/18 23:20:53.596": localExpected,
+		"2021-11-18T23:20:53.596": localExpected,
+		"2021-11-18 23:20:53,596": localExpected,
+	} {
+		tm, ok := (&Log{Time: s}).ParseTime()
+		c.Assert(ok, IsTrue, Commentf("time %s", s))
+		c.Assert(tm.Sub(expected) < time.Millisecond && expected.Sub(tm) < time.Millisecond, IsTrue, Commentf("time %s parsed as %v", s, tm))
+	}
+
+	_, ok := (&Log{Time: "Nov 18 23:20:53"}).ParseTime()
+	c.Assert(ok, IsFalse)
+	_, ok = (&Log{}).ParseTime()
+	c.Assert(ok, IsFalse)
+}
@@ -0,0 +1,175 @@
+package scanner
+
+import (
+	"bytes"
+	"path/filepath"
+	"strconv"
+)
+
+// LogfmtKeys are the key aliases of the log level, time, caller and message in logfmt log,
+// the first key found in the log is used
+type LogfmtKeys struct {
+	Level  []string
+	Time   []string
+	Caller []string
+	Msg    []string
+}
+
+// DefaultLogfmtKeys are the keys used by go-kit log and logrus
+var DefaultLogfmtKeys = LogfmtKeys{
+	Level:  []string{"level", "lvl"},
+	Time:   []string{"ts", "time", "t"},
+	Caller: []string{"caller"},
+	Msg:    []string{"msg", "message"},
+}
+
+// logfmtLogParser parses the log that is printed as key/value pairs per line, e.g.
+//
+//	ts=2021-11-18T23:20:53.596Z level=error caller=source_worker.go:605 msg="failed to update source status" err="context canceled"
+//
+// the values are quoted if they contain spaces, quotes or equal signs, and the quotes in them are escaped.
+// The keys except level, time, caller and message are kept as fields
+type logfmtLogParser struct {
+	keys LogfmtKeys
+}
+
+// NewLogfmtLogParser returns a logfmt log parser, the empty key aliases are set by DefaultLogfmtKeys
+func NewLogfmtLogParser(keys LogfmtKeys) LogParser {
+	if len(keys.Level) == 0 {
+		keys.Level = DefaultLogfmtKeys.Level
+	}
+	if len(keys.Time) == 0 {
+		keys.Time = DefaultLogfmtKeys.Time
+	}
+	if len(keys.Caller) == 0 {
+		keys.Caller = DefaultLogfmtKeys.Caller
+	}
+	if len(keys.Msg) == 0 {
+		keys.Msg = DefaultLogfmtKeys.Msg
+	}
+	return &logfmtLogParser{keys: keys}
+}
+
+func (p *logfmtLogParser) IsSuitable(content []byte) bool {
+	_, err := p.Parse(content)
+	return err == nil
+}
+
+func (p *logfmtLogParser) Parse(content []byte) (*Log, error) {
+	pairs, keys, ok := parseLogfmt(bytes.TrimSpace(content))
+	if !ok {
+		return nil, ErrNeedSkipLog
+	}
+
+	level, levelKey, ok := logfmtValue(pairs, p.keys.Level)
+	if !ok || !isVaildLogEvel(level) {
+		return nil, ErrNeedSkipLog
+	}
+	msg, msgKey, ok := logfmtValue(pairs, p.keys.Msg)
+	if !ok {
+		return nil, ErrNeedSkipLog
+	}
+
+	l := &Log{
+		Level: level,
+		// the signatures of log patterns are quoted
+		Msg: strconv.Quote(msg),
+	}
+	var timeKey string
+	l.Time, timeKey, _ = logfmtValue(pairs, p.keys.Time)
+	caller, callerKey, ok := logfmtValue(pairs, p.keys.Caller)
+	if ok {
+		// log patterns are matched by the file name and line number, e.g. source_worker.go:605
+		l.Position = filepath.Base(caller)
+	}
+
+	for _, key := range keys {
+		if key == levelKey || key == msgKey || key == callerKey || key == timeKey {
+			continue
+		}
+		if l.Fields == nil {
+			l.Fields = make(map[string]string)
+		}
+		l.Fields[key] = pairs[key]
+	}
+	return l, nil
+}
+
+// logfmtValue returns the value of the first key found in pairs, and the key
+func logfmtValue(pairs map[string]string, keys []string) (string, string, bool) {
+	for _, key := range keys {
+		if value, ok := pairs[key]; ok {
+			return value, key, true
+		}
+	}
+	return "", "", false
+}
+
+// parseLogfmt parses the key/value pairs of line, and returns the keys in order.
+// The key without value is allowed, e.g. "debug" in "debug level=info", but the first token must be a pair,
+// so that the lines of other formats are not taken as logfmt
+func parseLogfmt(line []byte) (map[string]string, []string, bool) {
+	var (
+		pairs = make(map[string]string)
+		keys  []string
+	)
+	for len(line) > 0 {
+		end := bytes.IndexAny(line, "= ")
+		if end < 0 {
+			end = len(line)
+		}
+		key := string(line[:end])
+		if key == "" || bytes.ContainsAny(line[:end], `"`) {
+			return nil, nil, false
+		}
+		line = line[end:]
+
+		var value string
+		if len(line) > 0 && line[0] == '=' {
+			line = line[1:]
+			if len(line) > 0 && line[0] == '"' {
+				end = logfmtQuoteEnd(line)
+				if end < 0 {
+					return nil, nil, false
+				}
+				unquoted, err := strconv.Unquote(string(line[:end]))
+				if err != nil {
+					return nil, nil, false
+				}
+				value, line = unquoted, line[end:]
+				if len(line) > 0 && line[0] != ' ' {
+					return nil, nil, false
+				}
+			} else {
+				end = bytes.IndexByte(line, ' ')
+				if end < 0 {
+					end = len(line)
+				}
+				value, line = string(line[:end]), line[end:]
+			}
+		} else if len(keys) == 0 {
+			return nil, nil, false
+		}
+
+		if _, ok := pairs[key]; !ok {
+			keys = append(keys, key)
+		}
+		pairs[key] = value
+		line = bytes.TrimLeft(line, " ")
+	}
+	return pairs, keys, len(keys) > 0
+}
+
+// logfmtQuoteEnd returns the end of quoted value that starts with '"', the escaped quotes are skipped,
+// it returns -1 if the value isn't closed
+func logfmtQuoteEnd(value []byte) int {
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return -1
+}
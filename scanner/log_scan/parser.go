@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"log"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -25,8 +27,66 @@ func RegisterLogParser(name string, l LogParser) {
 	hub[name] = l
 }
 
+// fileParser is the log parser that parses the log files matched by glob
+type fileParser struct {
+	glob string
+	name string
+}
+
+// fileParsers are the log parsers selected for log files, they take precedence over the parsers elected by log content
+var fileParsers []*fileParser
+
+// RegisterLogFileParser selects the parser in hub for the log files matched by glob,
+// the glob without slash matches file name, e.g. connector-*.log
+func RegisterLogFileParser(glob, name string) {
+	fileParsers = append(fileParsers, &fileParser{glob: glob, name: name})
+}
+
+// forcedParsers are the names of parsers forced for log files by path
+var forcedParsers = make(map[string]string)
+
+// HasLogParser reports whether the parser is registered in hub
+func HasLogParser(name string) bool {
+	_, ok := hub[name]
+	return ok
+}
+
+// ForceLogParser forces the log file to be parsed by the parser in hub, e.g. --logs zap:dm-worker.log
+func ForceLogParser(logPath, name string) error {
+	if _, ok := hub[name]; !ok {
+		return ErrNotFoundParser
+	}
+	forcedParsers[logPath] = name
+	return nil
+}
+
+// selectFileParser returns the parser forced or registered for the log file
+func selectFileParser(logPath string) (string, LogParser, bool) {
+	if name, ok := forcedParsers[logPath]; ok {
+		return name, hub[name], true
+	}
+	for _, fp := range fileParsers {
+		path := logPath
+		if !strings.Contains(fp.glob, "/") {
+			path = filepath.Base(logPath)
+		}
+		if ok, _ := filepath.Match(fp.glob, path); !ok {
+			continue
+		}
+		if parser, ok := hub[fp.name]; ok {
+			return fp.name, parser, true
+		}
+		log.Printf("log parser %s for log %s doesn't exist", fp.name, logPath)
+	}
+	return "", nil, false
+}
+
 func init() {
 	RegisterLogParser("zap", newZapLogParser())
+	RegisterLogParser("env_logger", newEnvLoggerParser())
+	RegisterLogParser("zap_json", NewJSONLogParser(DefaultJSONLogKeys))
+	RegisterLogParser("log", NewStdLogParser(""))
+	RegisterLogParser("logfmt", NewLogfmtLogParser(DefaultLogfmtKeys))
 }
 
 // LogParser defines a log parsing interface,
@@ -36,6 +96,13 @@ type LogParser interface {
 	IsSuitable(content []byte) bool
 }
 
+// MultiLineParser is the log parser whose log spans multiple lines,
+// the lines that continue the previous log are joined by newline before parsing
+type MultiLineParser interface {
+	LogParser
+	IsContinuation(line []byte) bool
+}
+
 // https://github.com/uber-go/zap
 type zapLogParser struct {
 }
@@ -144,5 +211,59 @@ func (z *ZapLog) Extract(line []byte) error {
 		return ErrLogIncomplete
 	}
 
+	z.extractFields()
 	return nil
 }
+
+// extractFields takes the rest ` [key=value]` pairs as Fields,
+// it stops at the first malformed field and leaves it in the Rest
+func (z *ZapLog) extractFields() {
+	for bytes.HasPrefix(z.Rest, constSpaceLsbrck) {
+		rest := z.Rest[len(constSpaceLsbrck):]
+
+		key, rest, ok := takeZapFieldToken(rest, '=')
+		if !ok || len(rest) == 0 || rest[0] != '=' {
+			return
+		}
+		value, rest, ok := takeZapFieldToken(rest[1:], ']')
+		if !ok || len(rest) == 0 || rest[0] != ']' {
+			return
+		}
+
+		if z.Fields == nil {
+			z.Fields = make(map[string]string)
+		}
+		z.Fields[key] = value
+		z.Rest = rest[1:]
+	}
+}
+
+// takeZapFieldToken takes a quoted string or the content until delimiter,
+// quoted string would be unquoted
+func takeZapFieldToken(content []byte, delimiter byte) (string, []byte, bool) {
+	if len(content) == 0 {
+		return "", content, false
+	}
+
+	if content[0] != '"' {
+		pos := bytes.IndexByte(content, delimiter)
+		if pos < 0 {
+			return "", content, false
+		}
+		return string(content[:pos]), content[pos:], true
+	}
+
+	for i := 1; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case '"':
+			quoted := string(content[:i+1])
+			if unquoted, err := strconv.Unquote(quoted); err == nil {
+				return unquoted, content[i+1:], true
+			}
+			return quoted[1 : len(quoted)-1], content[i+1:], true
+		}
+	}
+	return "", content, false
+}
@@ -90,15 +90,39 @@ func (t *testParserSuite) TestParseZapLog(c *C) {
 	}
 }
 
+func (t *testParserSuite) TestParseZapLogFields(c *C) {
+	parser := newZapLogParser()
+	lg, err := parser.Parse([]byte(`[2021/11/18 23:21:56.901 +00:00] [ERROR] [source_worker.go:605] ["failed"] [task=test] ["quoted key"="a \"b\" [c]"] [broken`))
+	c.Assert(err, IsNil)
+	c.Assert(lg.Fields, DeepEquals, map[string]string{
+		"task":       "test",
+		"quoted key": `a "b" [c]`,
+	})
+
+	lg, err = parser.Parse([]byte(`[2021/11/18 23:21:56.901 +00:00] [ERROR] [source_worker.go:605] ["failed"]`))
+	c.Assert(err, IsNil)
+	c.Assert(lg.Fields, IsNil)
+}
+
 func testGenerateStandardZapLogs() ([]string, []*Log) {
 	return []string{
 			`[2021/11/18 23:20:53.596 +00:00] [INFO] [printer.go:54] ["Welcome to dm-worker"] ["Release Version"=v5.2.0-master] ["Git Commit Hash"=c91af794e65f54222b46094b287042cdadaf3bcb] ["Git Branch"=master] ["UTC Build Time"="2021-11-18 23:16:34"] ["Go Version"="go version go1.16.10 linux/amd64"]`,
 			`[2021/11/18 23:20:53.596 +00:00] [INFO] [main.go:71] ["dm-worker config"="{\"name\":\"dm-worker-2\",\"log-level\":\"info\",\"log-file\":\"/log/dm-worker-2.log\",\"log-format\":\"text\",\"log-rotate\":\"\",\"join\":\"http://dm-master-0.dm-master.default:8261,http://dm-master-1.dm-master.default:8261,http://dm-master-2.dm-master.default:8261\",\"worker-addr\":\"0.0.0.0:8262\",\"advertise-addr\":\"dm-worker-2.dm-worker.default:8262\",\"config-file\":\"\",\"keepalive-ttl\":60,\"relay-keepalive-ttl\":1800,\"ssl-ca\":\"\",\"ssl-cert\":\"\",\"ssl-key\":\"\",\"cert-allowed-cn\":null}"]`,
 			`[2021/11/18 23:21:56.901 +00:00] [ERROR] [source_worker.go:605] ["failed to update source status"] [component="worker controller"] [error="[code=11011:class=functional:scope=internal:level=high], Message: 0-1-7195 is not mysql GTID set"] [errorVerbose="[code=11011:class=functional:scope=internal:level=high], Message: 0-1-7195 is not mysql GTID set\ngithub.com/pingcap/ticdc/dm/pkg/terror.(*Error).Generate\n\tgithub.com/pingcap/ticdc/dm/pkg/terror/terror.go:267\ngithub.com/pingcap/ticdc/dm/pkg/gtid.(*MySQLGTIDSet).Set\n\tgithub.com/pingcap/ticdc/dm/pkg/gtid/gtid.go:122\ngithub.com/pingcap/ticdc/dm/pkg/binlog.(*Location).SetGTID\n\tgithub.com/pingcap/ticdc/dm/pkg/binlog/position.go:408\ngithub.com/pingcap/ticdc/dm/dm/worker.(*SourceWorker).updateSourceStatus\n\tgithub.com/pingcap/ticdc/dm/dm/worker/source_worker.go:251\ngithub.com/pingcap/ticdc/dm/dm/worker.(*SourceWorker).QueryStatus\n\tgithub.com/pingcap/ticdc/dm/dm/worker/source_worker.go:604\ngithub.com/pingcap/ticdc/dm/dm/worker.(*Server).QueryStatus\n\tgithub.com/pingcap/ticdc/dm/dm/worker/server.go:797\ngithub.com/pingcap/ticdc/dm/dm/pb._Worker_QueryStatus_Handler\n\tgithub.com/pingcap/ticdc/dm/dm/pb/dmworker.pb.go:2807\ngoogle.golang.org/grpc.(*Server).processUnaryRPC\n\tgoogle.golang.org/grpc@v1.40.0/server.go:1082\ngoogle.golang.org/grpc.(*Server).handleStream\n\tgoogle.golang.org/grpc@v1.40.0/server.go:1405\ngoogle.golang.org/grpc.(*Server).serveStreams.func1.1\n\tgoogle.golang.org/grpc@v1.40.0/server.go:746\nruntime.goexit\n\truntime/asm_amd64.s:1371"]`,
 		}, []*Log{
-			{Time: "2021/11/18 23:20:53.596 +00:00", Level: "INFO", Position: "printer.go:54", Msg: "\"Welcome to dm-worker\""},
+			{Time: "2021/11/18 23:20:53.596 +00:00", Level: "INFO", Position: "printer.go:54", Msg: "\"Welcome to dm-worker\"", Fields: map[string]string{
+				"Release Version": "v5.2.0-master",
+				"Git Commit Hash": "c91af794e65f54222b46094b287042cdadaf3bcb",
+				"Git Branch":      "master",
+				"UTC Build Time":  "2021-11-18 23:16:34",
+				"Go Version":      "go version go1.16.10 linux/amd64",
+			}},
 			{Time: "2021/11/18 23:20:53.596 +00:00", Level: "INFO", Position: "main.go:71", Msg: `"dm-worker config"="{\"name\":\"dm-worker-2\",\"log-level\":\"info\",\"log-file\":\"/log/dm-worker-2.log\",\"log-format\":\"text\",\"log-rotate\":\"\",\"join\":\"http://dm-master-0.dm-master.default:8261,http://dm-master-1.dm-master.default:8261,http://dm-master-2.dm-master.default:8261\",\"worker-addr\":\"0.0.0.0:8262\",\"advertise-addr\":\"dm-worker-2.dm-worker.default:8262\",\"config-file\":\"\",\"keepalive-ttl\":60,\"relay-keepalive-ttl\":1800,\"ssl-ca\":\"\",\"ssl-cert\":\"\",\"ssl-key\":\"\",\"cert-allowed-cn\":null}"`},
-			{Time: "2021/11/18 23:21:56.901 +00:00", Level: "ERROR", Position: "source_worker.go:605", Msg: "\"failed to update source status\""},
+			{Time: "2021/11/18 23:21:56.901 +00:00", Level: "ERROR", Position: "source_worker.go:605", Msg: "\"failed to update source status\"", Fields: map[string]string{
+				"component":    "worker controller",
+				"error":        "[code=11011:class=functional:scope=internal:level=high], Message: 0-1-7195 is not mysql GTID set",
+				"errorVerbose": "[code=11011:class=functional:scope=internal:level=high], Message: 0-1-7195 is not mysql GTID set\ngithub.com/pingcap/ticdc/dm/pkg/terror.(*Error).Generate\n\tgithub.com/pingcap/ticdc/dm/pkg/terror/terror.go:267\ngithub.com/pingcap/ticdc/dm/pkg/gtid.(*MySQLGTIDSet).Set\n\tgithub.com/pingcap/ticdc/dm/pkg/gtid/gtid.go:122\ngithub.com/pingcap/ticdc/dm/pkg/binlog.(*Location).SetGTID\n\tgithub.com/pingcap/ticdc/dm/pkg/binlog/position.go:408\ngithub.com/pingcap/ticdc/dm/dm/worker.(*SourceWorker).updateSourceStatus\n\tgithub.com/pingcap/ticdc/dm/dm/worker/source_worker.go:251\ngithub.com/pingcap/ticdc/dm/dm/worker.(*SourceWorker).QueryStatus\n\tgithub.com/pingcap/ticdc/dm/dm/worker/source_worker.go:604\ngithub.com/pingcap/ticdc/dm/dm/worker.(*Server).QueryStatus\n\tgithub.com/pingcap/ticdc/dm/dm/worker/server.go:797\ngithub.com/pingcap/ticdc/dm/dm/pb._Worker_QueryStatus_Handler\n\tgithub.com/pingcap/ticdc/dm/dm/pb/dmworker.pb.go:2807\ngoogle.golang.org/grpc.(*Server).processUnaryRPC\n\tgoogle.golang.org/grpc@v1.40.0/server.go:1082\ngoogle.golang.org/grpc.(*Server).handleStream\n\tgoogle.golang.org/grpc@v1.40.0/server.go:1405\ngoogle.golang.org/grpc.(*Server).serveStreams.func1.1\n\tgoogle.golang.org/grpc@v1.40.0/server.go:746\nruntime.goexit\n\truntime/asm_amd64.s:1371",
+			}},
 		}
 }
 
@@ -110,7 +134,7 @@ func testGenerateSpecialZapLogs() ([]string, []error) {
 		`xxxx][yyyyy`,
 		`][zzzz]`,
 		`[2021/11/18 23:21:56.901 +00:00] [ERROR] [source_worker.go:605] ["failed to update source status"] [component="worker controller"]`,
-	}, []error{ErrLogIncomplete, ErrZapLog, ErrZapLog, ErrZapLog, ErrZapLog, nil}
+	}, []error{ErrLogIncomplete, ErrNeedSkipLog, ErrNeedSkipLog, ErrNeedSkipLog, ErrNeedSkipLog, nil}
 }
 
 func testGenerateInValidZapLogs() ([]string, []error) {
@@ -121,5 +145,199 @@ func testGenerateInValidZapLogs() ([]string, []error) {
 		`[2021/11/18 23:20:53.596 +00:00] xxx`,
 		`xxxxx`,
 		`[2021/11/18 23:21:56.901 +00:00] [ERROR] [source_worker.go:605] ["failed to update source status"] [component="worker controller"]`,
-	}, []error{ErrZapLog, ErrZapLog, ErrZapLog, ErrZapLog, ErrZapLog, nil}
+	}, []error{ErrNeedSkipLog, ErrNeedSkipLog, ErrNeedSkipLog, ErrNeedSkipLog, ErrNeedSkipLog, nil}
+}
+
+func (t *testParserSuite) TestParseEnvLoggerLog(c *C) {
+	parser := hub["env_logger"]
+
+	l, err := parser.Parse([]byte("[2021-08-17T09:05:41Z ERROR tikv::server] failed to connect 127.0.0.1:20160: timeout"))
+	c.Assert(err, IsNil)
+	c.Assert(l, DeepEquals, &Log{
+		Time:   "2021-08-17T09:05:41Z",
+		Level:  "ERROR",
+		Msg:    `"failed to connect 127.0.0.1:20160: timeout"`,
+		Fields: map[string]string{"target": "tikv::server"},
+	})
+
+	// level is padded, and timestamp is disabled
+	l, err = parser.Parse([]byte(`[INFO  tikv::server] listening on "0.0.0.0"`))
+	c.Assert(err, IsNil)
+	c.Assert(l, DeepEquals, &Log{
+		Level:  "INFO",
+		Msg:    `"listening on \"0.0.0.0\""`,
+		Fields: map[string]string{"target": "tikv::server"},
+	})
+
+	// target is disabled
+	l, err = parser.Parse([]byte("[2021-08-17T09:05:41Z WARN ] retry"))
+	c.Assert(err, IsNil)
+	c.Assert(l.Fields, IsNil)
+	c.Assert(l.Msg, Equals, `"retry"`)
+
+	// zap log is not env_logger log
+	contents, _ := testGenerateStandardZapLogs()
+	for _, content := range contents {
+		c.Assert(parser.IsSuitable([]byte(content)), IsFalse)
+	}
+	_, err = parser.Parse([]byte("[2021-08-17T09:05:41Z ERROR tikv::server"))
+	c.Assert(err, Equals, ErrLogIncomplete)
+	_, err = parser.Parse([]byte("[2021-08-17T09:05:41Z NOTICE tikv::server] msg"))
+	c.Assert(err, Equals, ErrNeedSkipLog)
+}
+
+func (t *testParserSuite) TestParseJSONLog(c *C) {
+	parser := hub["zap_json"]
+
+	l, err := parser.Parse([]byte(`{"level":"error","ts":1637277716.901,"caller":"worker/source_worker.go:605","msg":"failed to update source status","component":"worker controller","error":"[code=11011:class=functional:scope=internal:level=high], Message: 0-1-7195 is not mysql GTID set","retry":3}`))
+	c.Assert(err, IsNil)
+	c.Assert(l, DeepEquals, &Log{
+		Time:     "1637277716.901",
+		Level:    "error",
+		Position: "source_worker.go:605",
+		Msg:      `"failed to update source status"`,
+		Fields: map[string]string{
+			"component": "worker controller",
+			"error":     "[code=11011:class=functional:scope=internal:level=high], Message: 0-1-7195 is not mysql GTID set",
+			"retry":     "3",
+		},
+	})
+
+	// non-JSON lines interleaved in the log file are skipped
+	for _, content := range []string{
+		"panic: runtime error: invalid memory address or nil pointer dereference",
+		"goroutine 1 [running]:",
+		`{"level":"error","msg":"truncated`,
+		`{"level":"debug","msg":"debug log is not covered"}`,
+	} {
+		_, err = parser.Parse([]byte(content))
+		c.Assert(err, Equals, ErrNeedSkipLog)
+	}
+	contents, _ := testGenerateStandardZapLogs()
+	for _, content := range contents {
+		c.Assert(parser.IsSuitable([]byte(content)), IsFalse)
+	}
+
+	// customized key names
+	parser = NewJSONLogParser(JSONLogKeys{Level: "severity", Time: "timestamp", Msg: "message"})
+	l, err = parser.Parse([]byte(`{"severity":"WARN","timestamp":"2021-11-18T23:21:56.901Z","caller":"main.go:12","message":"slow query \"select 1\""}`))
+	c.Assert(err, IsNil)
+	c.Assert(l, DeepEquals, &Log{
+		Time:     "2021-11-18T23:21:56.901Z",
+		Level:    "WARN",
+		Position: "main.go:12",
+		Msg:      `"slow query \"select 1\""`,
+	})
+}
+
+func (t *testParserSuite) TestParseStdLog(c *C) {
+	parser := hub["log"]
+
+	// log.LstdFlags | log.Lshortfile
+	l, err := parser.Parse([]byte("2021/11/18 23:20:53 source_worker.go:605: failed to update source status\n"))
+	c.Assert(err, IsNil)
+	c.Assert(l, DeepEquals, &Log{
+		Time:     "2021/11/18 23:20:53",
+		Position: "source_worker.go:605",
+		Msg:      `"failed to update source status"`,
+	})
+
+	for _, content := range []string{
+		// log.Ldate | log.Ltime | log.Lmicroseconds | log.Llongfile | log.LUTC
+		"2021/11/18 23:20:53.596123 /go/src/dm/worker/source_worker.go:605: [error] failed to update source status",
+		// log.Lmicroseconds | log.Lshortfile with prefix
+		"[worker] 23:20:53.596123 source_worker.go:605: [error] failed to update source status",
+		// log.Lshortfile
+		"source_worker.go:605: [error] failed to update source status",
+	} {
+		l, err = parser.Parse([]byte(content))
+		c.Assert(err, IsNil)
+		c.Assert(l.Level, Equals, "error")
+		c.Assert(l.Position, Equals, "source_worker.go:605")
+		c.Assert(l.Msg, Equals, `"[error] failed to update source status"`)
+	}
+
+	// log.LstdFlags without file, the level is inferred by the message prefix
+	l, err = parser.Parse([]byte("2021/11/18 23:20:53 ERROR: connection refused"))
+	c.Assert(err, IsNil)
+	c.Assert(l, DeepEquals, &Log{
+		Time:  "2021/11/18 23:20:53",
+		Level: "error",
+		Msg:   `"ERROR: connection refused"`,
+	})
+
+	// log.Lmsgprefix with the configured prefix
+	l, err = NewStdLogParser("worker: ").Parse([]byte("2021/11/18 23:20:53 main.go:12: worker: WARNING: retry"))
+	c.Assert(err, IsNil)
+	c.Assert(l.Level, Equals, "warn")
+	c.Assert(l.Msg, Equals, `"WARNING: retry"`)
+
+	// logs without header and logs of other formats are not standard library logs
+	for _, content := range []string{
+		"failed to update source status",
+		"panic: runtime error: invalid memory address or nil pointer dereference",
+		"[2021-08-17T09:05:41Z ERROR tikv::server] listening on 0.0.0.0",
+		`{"level":"error","ts":1637277716.901,"caller":"worker/source_worker.go:605","msg":"failed"}`,
+	} {
+		c.Assert(parser.IsSuitable([]byte(content)), IsFalse)
+	}
+	contents, _ := testGenerateStandardZapLogs()
+	for _, content := range contents {
+		c.Assert(parser.IsSuitable([]byte(content)), IsFalse)
+	}
+}
+
+func (t *testParserSuite) TestParseLogfmtLog(c *C) {
+	parser := hub["logfmt"]
+
+	l, err := parser.Parse([]byte(`ts=2021-11-18T23:20:53.596Z level=error caller=worker/source_worker.go:605 msg="failed to update source \"mysql-replica-01\"" err="context canceled" retry=3 dry-run`))
+	c.Assert(err, IsNil)
+	c.Assert(l, DeepEquals, &Log{
+		Time:     "2021-11-18T23:20:53.596Z",
+		Level:    "error",
+		Position: "source_worker.go:605",
+		Msg:      `"failed to update source \"mysql-replica-01\""`,
+		Fields: map[string]string{
+			"err":     "context canceled",
+			"retry":   "3",
+			"dry-run": "",
+		},
+	})
+
+	// the aliases of keys
+	l, err = parser.Parse([]byte(`time="2021-11-18 23:20:53" lvl=warn message=retry`))
+	c.Assert(err, IsNil)
+	c.Assert(l, DeepEquals, &Log{
+		Time:  "2021-11-18 23:20:53",
+		Level: "warn",
+		Msg:   `"retry"`,
+	})
+
+	for _, content := range []string{
+		`level=error msg="unclosed`,
+		`level=error msg="bad quote"x`,
+		`level=debug msg="debug log is not covered"`,
+		`level=error err="no message"`,
+		"failed level=error msg=retry",
+		"[2021-08-17T09:05:41Z ERROR tikv::server] listening on 0.0.0.0",
+		"2021/11/18 23:20:53 source_worker.go:605: failed to update source status",
+		`{"level":"error","ts":1637277716.901,"caller":"worker/source_worker.go:605","msg":"failed"}`,
+	} {
+		_, err = parser.Parse([]byte(content))
+		c.Assert(err, Equals, ErrNeedSkipLog)
+	}
+	contents, _ := testGenerateStandardZapLogs()
+	for _, content := range contents {
+		c.Assert(parser.IsSuitable([]byte(content)), IsFalse)
+	}
+
+	// customized key aliases
+	parser = NewLogfmtLogParser(LogfmtKeys{Level: []string{"severity"}, Caller: []string{"source", "src"}})
+	l, err = parser.Parse([]byte(`severity=ERROR src=main.go:12 msg=exit`))
+	c.Assert(err, IsNil)
+	c.Assert(l, DeepEquals, &Log{
+		Level:    "ERROR",
+		Position: "main.go:12",
+		Msg:      `"exit"`,
+	})
 }
@@ -2,8 +2,21 @@ package scanner
 
 import (
 	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	bzip2Magic = []byte("BZh")
+	zstdMagic  = []byte{0x28, 0xb5, 0x2f, 0xfd}
 )
 
 // LogReader defines the interface to read the log, which can read the log from different locations,
@@ -15,13 +28,26 @@ type LogReader interface {
 	Close() error
 }
 
-// fileLogReader implements the LogReader interface, which can read the log from the log file on the disk
+// fileLogReader implements the LogReader interface, which can read the log from the log file on the disk,
+// the gzip, bzip2 and zstd compressed files are decompressed by their magic bytes
 type fileLogReader struct {
 	logPath string
+	// fd is nil if the log is a member of archive
 	fd      *os.File
+	fileID  string
+	content io.Reader
 	scanner *bufio.Scanner
 
+	// closers close the decompressors
+	closers []func() error
+
 	buff []byte
+
+	// cp is the checkpoint after the last line, advance and ended are the length and whether the line
+	// is ended by newline, which are set by split
+	cp      Checkpoint
+	advance int
+	ended   bool
 }
 
 func NewFileReader(logPath string) (LogReader, error) {
@@ -30,22 +56,88 @@ func NewFileReader(logPath string) (LogReader, error) {
 		return nil, err
 	}
 
-	buff := make([]byte, 102400)
+	reader, err := newLogReader(logPath, fd)
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+	reader.fd = fd
+	if info, err := fd.Stat(); err == nil {
+		reader.fileID = fileIdentity(info)
+	}
+	reader.cp = Checkpoint{FilePath: logPath, FileID: reader.fileID}
+	return reader, nil
+}
 
-	scanner := bufio.NewScanner(fd)
-	// Avoid size of single-line log is too large, resulting in reading errors
-	scanner.Buffer(buff, 102400)
-	return &fileLogReader{
+// newLogReader returns the reader of log content, which may be compressed
+func newLogReader(logPath string, r io.Reader) (*fileLogReader, error) {
+	content, closers, err := decompress(logPath, r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &fileLogReader{
 		logPath: logPath,
-		fd:      fd,
-		scanner: scanner,
-		buff:    buff,
-	}, nil
+		content: content,
+		closers: closers,
+		buff:    make([]byte, 102400),
+	}
+	f.resetScanner(content)
+	return f, nil
+}
+
+func (f *fileLogReader) resetScanner(r io.Reader) {
+	f.scanner = bufio.NewScanner(r)
+	// Avoid size of single-line log is too large, resulting in reading errors
+	f.scanner.Buffer(f.buff, 102400)
+	f.scanner.Split(f.split)
+}
+
+// split splits the lines like bufio.ScanLines, and records the length of line to calculate the offset
+func (f *fileLogReader) split(data []byte, atEOF bool) (int, []byte, error) {
+	advance, token, err := bufio.ScanLines(data, atEOF)
+	if advance > 0 {
+		f.advance, f.ended = advance, data[advance-1] == '\n'
+	}
+	return advance, token, err
+}
+
+// decompress returns the reader of decompressed content by the magic bytes of file
+func decompress(logPath string, r io.Reader) (io.Reader, []func() error, error) {
+	reader := bufio.NewReader(r)
+	magic, _ := reader.Peek(len(zstdMagic))
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open gzip log %s failed: %v", logPath, err)
+		}
+		return gz, []func() error{gz.Close}, nil
+	case bytes.HasPrefix(magic, bzip2Magic):
+		return bzip2.NewReader(reader), nil, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open zstd log %s failed: %v", logPath, err)
+		}
+		return zr, []func() error{func() error {
+			zr.Close()
+			return nil
+		}}, nil
+	}
+	return reader, nil, nil
 }
 
 func (f *fileLogReader) Scan() ([]byte, error) {
 	next := f.scanner.Scan()
 	if next {
+		// only the last line may be not ended by newline, it may be still being written if the file is plain,
+		// so it's left to be read again after resuming from the checkpoint
+		if !f.ended && f.plain() {
+			return nil, io.EOF
+		}
+		f.cp.Offset += int64(f.advance)
 		return f.scanner.Bytes(), f.scanner.Err()
 	}
 
@@ -56,6 +148,153 @@ func (f *fileLogReader) Scan() ([]byte, error) {
 	return nil, io.EOF
 }
 
+// plain reports whether the reader reads a plain file on disk, which may be appended by the program
+func (f *fileLogReader) plain() bool {
+	_, plain := f.content.(*bufio.Reader)
+	return f.fd != nil && plain
+}
+
+func (f *fileLogReader) checkpoint() Checkpoint {
+	return f.cp
+}
+
+// resume skips the content before the offset of checkpoint, the plain file is seeked to the offset,
+// and the compressed file is decompressed and discarded until the offset
+func (f *fileLogReader) resume(cp *Checkpoint) (bool, error) {
+	if f.fd == nil || f.fileID == "" || cp.FileID != f.fileID {
+		return false, nil
+	}
+
+	// the plain content is read by the bufio reader that peeked the magic bytes
+	if f.plain() {
+		info, err := f.fd.Stat()
+		if err != nil {
+			return false, err
+		}
+		if info.Size() < cp.Offset {
+			// truncated
+			return false, nil
+		}
+		if _, err := f.fd.Seek(cp.Offset, io.SeekStart); err != nil {
+			return false, err
+		}
+		f.resetScanner(f.fd)
+	} else if _, err := io.CopyN(ioutil.Discard, f.content, cp.Offset); err != nil && err != io.EOF {
+		return false, err
+	}
+
+	f.cp.Offset = cp.Offset
+	return true, nil
+}
+
 func (f *fileLogReader) Close() error {
+	for _, closer := range f.closers {
+		closer()
+	}
+	if f.fd == nil {
+		return nil
+	}
 	return f.fd.Close()
 }
+
+// rotatedLogReader reads the rotated log files one by one as one log
+type rotatedLogReader struct {
+	logPaths []string
+	current  LogReader
+	// cp is the checkpoint of the file that the last line is read from
+	cp Checkpoint
+}
+
+// NewRotatedFileReader returns the reader of log files in order, e.g. the rotated files of one log in chronological order
+func NewRotatedFileReader(logPaths []string) LogReader {
+	return &rotatedLogReader{logPaths: logPaths}
+}
+
+func (r *rotatedLogReader) Scan() ([]byte, error) {
+	for {
+		if r.current == nil {
+			if len(r.logPaths) == 0 {
+				return nil, io.EOF
+			}
+
+			reader, err := NewFileReader(r.logPaths[0])
+			if err != nil {
+				return nil, err
+			}
+			r.current, r.logPaths = reader, r.logPaths[1:]
+		}
+
+		line, err := r.current.Scan()
+		if err != io.EOF {
+			if cr, ok := r.current.(checkpointReader); ok && err == nil {
+				r.cp = cr.checkpoint()
+			}
+			return line, err
+		}
+		if err := r.current.Close(); err != nil {
+			return nil, err
+		}
+		r.current = nil
+	}
+}
+
+func (r *rotatedLogReader) checkpoint() Checkpoint {
+	return r.cp
+}
+
+// resume skips the files before the file of checkpoint, and the content of the file before the offset of checkpoint.
+// It refuses to resume if the file of checkpoint isn't found, e.g. it's deleted or compressed into a new file,
+// because the files newer than it are unknown and reading all files counts the scanned logs again
+func (r *rotatedLogReader) resume(cp *Checkpoint) (bool, error) {
+	for i, path := range r.logPaths {
+		info, err := os.Stat(path)
+		if err != nil || fileIdentity(info) != cp.FileID {
+			continue
+		}
+
+		reader, err := NewFileReader(path)
+		if err != nil {
+			return false, err
+		}
+		r.current, r.logPaths = reader, r.logPaths[i+1:]
+		r.cp = Checkpoint{FilePath: path, FileID: cp.FileID}
+		return reader.(checkpointReader).resume(cp)
+	}
+	return false, fmt.Errorf("%w: file %s isn't found in the rotated logs %v", ErrCheckpointLost, cp.FilePath, r.logPaths)
+}
+
+func (r *rotatedLogReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	return r.current.Close()
+}
+
+// binarySniffLen is the length of content that is sniffed to find binary file
+const binarySniffLen = 8000
+
+// IsBinaryLog reports whether the file is a binary file, e.g. a core dump, by finding NUL in the beginning of content,
+// the compressed files are decompressed before sniffing
+func IsBinaryLog(logPath string) (bool, error) {
+	fd, err := os.Open(logPath)
+	if err != nil {
+		return false, err
+	}
+	defer fd.Close()
+
+	reader, closers, err := decompress(logPath, fd)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		for _, closer := range closers {
+			closer()
+		}
+	}()
+
+	content, err := ioutil.ReadAll(io.LimitReader(reader, binarySniffLen))
+	if err != nil && len(content) == 0 {
+		return false, err
+	}
+	return bytes.IndexByte(content, 0) >= 0, nil
+}
@@ -0,0 +1,67 @@
+package scanner
+
+import (
+	"bytes"
+	"path/filepath"
+	"strconv"
+)
+
+// regexLogParser parses the log by the regexp of user-defined parser rule
+type regexLogParser struct {
+	rule *ParserRule
+}
+
+// NewRegexLogParser returns a log parser of the compiled parser rule, the rules in ParserConfig are compiled by LoadParserConfig
+func NewRegexLogParser(rule *ParserRule) LogParser {
+	return &regexLogParser{rule: rule}
+}
+
+func (r *regexLogParser) IsSuitable(content []byte) bool {
+	_, err := r.Parse(content)
+	return err == nil
+}
+
+func (r *regexLogParser) IsContinuation(line []byte) bool {
+	return r.rule.continuation != nil && r.rule.continuation.Match(line)
+}
+
+func (r *regexLogParser) Parse(content []byte) (*Log, error) {
+	// the pattern describes the first line of log, the continuation lines are kept in the raw log
+	if i := bytes.IndexByte(content, '\n'); i >= 0 {
+		content = content[:i]
+	}
+	match := r.rule.pattern.FindSubmatch(content)
+	if match == nil {
+		return nil, ErrNeedSkipLog
+	}
+
+	l := &Log{}
+	for i, name := range r.rule.pattern.SubexpNames() {
+		if name == "" || match[i] == nil {
+			continue
+		}
+
+		value := string(match[i])
+		switch name {
+		case timeGroup:
+			l.Time = value
+		case levelGroup:
+			if level, ok := r.rule.Levels[value]; ok {
+				value = level
+			}
+			l.Level = value
+		case positionGroup:
+			// log patterns are matched by the file name and line number, e.g. source_worker.go:605
+			l.Position = filepath.Base(value)
+		case msgGroup:
+			// the signatures of log patterns are quoted
+			l.Msg = strconv.Quote(value)
+		default:
+			if l.Fields == nil {
+				l.Fields = make(map[string]string)
+			}
+			l.Fields[name] = value
+		}
+	}
+	return l, nil
+}
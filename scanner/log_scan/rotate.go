@@ -0,0 +1,111 @@
+package scanner
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lumberjackTimeFormats are the time formats in the names of the backups rotated by lumberjack,
+// e.g. dm-worker-2021-11-18T23-20-53.000.log.gz
+var lumberjackTimeFormats = []string{"2006-01-02T15-04-05.000", "2006-01-02T15-04-05"}
+
+// rotatedLog is a backup of rotated log
+type rotatedLog struct {
+	path string
+	// time is the rotating time in the name of lumberjack backup
+	time time.Time
+	// index is the number suffix of logrotate backup, e.g. 2 of dm-worker.log.2.gz, the larger one is older
+	index int
+}
+
+// RotatedLogFiles returns the log file and its rotated backups in chronological order, the log file is the last one.
+// The backups rotated by lumberjack, e.g. dm-worker-2021-11-18T23-20-53.log.gz, are sorted by the time in their names,
+// and the backups rotated by logrotate, e.g. dm-worker.log.1 and dm-worker.log.2.gz, are older than them.
+// The log file is returned alone if it's a backup
+func RotatedLogFiles(logPath string) ([]string, error) {
+	dir, name := filepath.Split(logPath)
+	if isRotatedLog(name) {
+		return []string{logPath}, nil
+	}
+
+	entries, err := ioutil.ReadDir(filepath.Clean(dir + "."))
+	if err != nil {
+		return nil, err
+	}
+
+	ext := filepath.Ext(name)
+	lumberjack := regexp.MustCompile("^" + regexp.QuoteMeta(strings.TrimSuffix(name, ext)+"-") + `(.+)` + regexp.QuoteMeta(ext) + `(?:\.gz|\.bz2|\.zst)?$`)
+	logrotate := regexp.MustCompile("^" + regexp.QuoteMeta(name) + `\.(\d+)(?:\.gz|\.bz2|\.zst)?$`)
+
+	var backups []*rotatedLog
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if m := lumberjack.FindStringSubmatch(entry.Name()); m != nil {
+			if t, ok := parseLumberjackTime(m[1]); ok {
+				backups = append(backups, &rotatedLog{path: dir + entry.Name(), time: t})
+			}
+		} else if m := logrotate.FindStringSubmatch(entry.Name()); m != nil {
+			index, _ := strconv.Atoi(m[1])
+			backups = append(backups, &rotatedLog{path: dir + entry.Name(), index: index})
+		}
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		a, b := backups[i], backups[j]
+		if a.time.IsZero() != b.time.IsZero() {
+			return a.time.IsZero()
+		}
+		if !a.time.Equal(b.time) {
+			return a.time.Before(b.time)
+		}
+		return a.index > b.index
+	})
+
+	files := make([]string, 0, len(backups)+1)
+	for _, backup := range backups {
+		files = append(files, backup.path)
+	}
+	if _, err := os.Stat(logPath); err == nil || len(files) == 0 {
+		files = append(files, logPath)
+	}
+	return files, nil
+}
+
+// isRotatedLog reports whether the file name is a backup of rotated log
+func isRotatedLog(name string) bool {
+	for _, suffix := range []string{".gz", ".bz2", ".zst"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	if ext := filepath.Ext(name); len(ext) > 1 {
+		if _, err := strconv.Atoi(ext[1:]); err == nil {
+			return true
+		}
+	}
+
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	for _, format := range lumberjackTimeFormats {
+		if len(base) > len(format) && base[len(base)-len(format)-1] == '-' {
+			if _, ok := parseLumberjackTime(base[len(base)-len(format):]); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseLumberjackTime(s string) (time.Time, bool) {
+	for _, format := range lumberjackTimeFormats {
+		if t, err := time.Parse(format, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
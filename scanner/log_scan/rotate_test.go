@@ -0,0 +1,164 @@
+package scanner
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testRotateSuite{})
+
+type testRotateSuite struct {
+}
+
+const (
+	// testBzip2Log is "bzip2 line 1\nbzip2 line 2\n" compressed by bzip2
+	testBzip2Log = "QlpoOTFBWSZTWZXv8I0AAAVZgAAQQAAwABIlQBAgACCqhpoZCAaaaIiWm1JUpaVlvi7kinChISvf4Ro="
+	// testZstdLog is "zstd line\n" compressed by zstd
+	testZstdLog = "KLUv/QRYUQAAenN0ZCBsaW5lCvv/6mo="
+)
+
+func testWriteGzip(c *C, path, content string) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(content))
+	c.Assert(err, IsNil)
+	c.Assert(w.Close(), IsNil)
+	c.Assert(ioutil.WriteFile(path, buf.Bytes(), 0o644), IsNil)
+}
+
+func testWriteBase64(c *C, path, content string) {
+	data, err := base64.StdEncoding.DecodeString(content)
+	c.Assert(err, IsNil)
+	c.Assert(ioutil.WriteFile(path, data, 0o644), IsNil)
+}
+
+func testReadLines(c *C, reader LogReader) []string {
+	var lines []string
+	for {
+		line, err := reader.Scan()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, IsNil)
+		lines = append(lines, string(line))
+	}
+	c.Assert(reader.Close(), IsNil)
+	return lines
+}
+
+func (t *testRotateSuite) TestCompressedReader(c *C) {
+	dir := c.MkDir()
+
+	path := filepath.Join(dir, "dm-worker.log.gz")
+	testWriteGzip(c, path, "gzip line 1\ngzip line 2\n")
+	reader, err := NewFileReader(path)
+	c.Assert(err, IsNil)
+	c.Assert(testReadLines(c, reader), DeepEquals, []string{"gzip line 1", "gzip line 2"})
+
+	// the compressed file is detected by magic bytes instead of extension
+	path = filepath.Join(dir, "dm-worker.log.1")
+	testWriteBase64(c, path, testBzip2Log)
+	reader, err = NewFileReader(path)
+	c.Assert(err, IsNil)
+	c.Assert(testReadLines(c, reader), DeepEquals, []string{"bzip2 line 1", "bzip2 line 2"})
+
+	path = filepath.Join(dir, "dm-worker.log.zst")
+	testWriteBase64(c, path, testZstdLog)
+	reader, err = NewFileReader(path)
+	c.Assert(err, IsNil)
+	c.Assert(testReadLines(c, reader), DeepEquals, []string{"zstd line"})
+
+	// the truncated compressed file fails to scan instead of ending early
+	data, err := base64.StdEncoding.DecodeString(testZstdLog)
+	c.Assert(err, IsNil)
+	c.Assert(ioutil.WriteFile(path, data[:len(data)-6], 0o644), IsNil)
+	reader, err = NewFileReader(path)
+	c.Assert(err, IsNil)
+	for err == nil {
+		_, err = reader.Scan()
+	}
+	c.Assert(err, Not(Equals), io.EOF)
+	c.Assert(reader.Close(), IsNil)
+}
+
+func (t *testRotateSuite) TestBinaryLog(c *C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "core.1")
+	c.Assert(ioutil.WriteFile(path, []byte("\x7fELF\x02\x01\x01\x00\x00"), 0o644), IsNil)
+	binary, err := IsBinaryLog(path)
+	c.Assert(err, IsNil)
+	c.Assert(binary, IsTrue)
+
+	path = filepath.Join(dir, "dm-worker.log.gz")
+	testWriteGzip(c, path, "gzip line\n")
+	binary, err = IsBinaryLog(path)
+	c.Assert(err, IsNil)
+	c.Assert(binary, IsFalse)
+
+	_, err = IsBinaryLog(filepath.Join(dir, "missing.log"))
+	c.Assert(err, NotNil)
+}
+
+func (t *testRotateSuite) TestRotatedLogFiles(c *C) {
+	dir := c.MkDir()
+	for name, content := range map[string]string{
+		"dm-worker.log":                            "active\n",
+		"dm-worker-2021-11-18T23-20-53.log.gz":     "",
+		"dm-worker-2021-11-17T10-00-00.000.log.gz": "",
+		"dm-worker-2021-11-18T08-00-00.log":        "",
+		"dm-worker.log.1":                          "",
+		"dm-worker.log.2.gz":                       "",
+		"dm-master.log.1":                          "",
+		"dm-worker-backup.log":                     "",
+	} {
+		c.Assert(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0o644), IsNil)
+	}
+
+	files, err := RotatedLogFiles(filepath.Join(dir, "dm-worker.log"))
+	c.Assert(err, IsNil)
+	for i := range files {
+		files[i] = filepath.Base(files[i])
+	}
+	c.Assert(files, DeepEquals, []string{
+		"dm-worker.log.2.gz",
+		"dm-worker.log.1",
+		"dm-worker-2021-11-17T10-00-00.000.log.gz",
+		"dm-worker-2021-11-18T08-00-00.log",
+		"dm-worker-2021-11-18T23-20-53.log.gz",
+		"dm-worker.log",
+	})
+
+	// the backup is read alone
+	path := filepath.Join(dir, "dm-worker-2021-11-18T23-20-53.log.gz")
+	files, err = RotatedLogFiles(path)
+	c.Assert(err, IsNil)
+	c.Assert(files, DeepEquals, []string{path})
+	files, err = RotatedLogFiles(filepath.Join(dir, "dm-master.log"))
+	c.Assert(err, IsNil)
+	c.Assert(files, DeepEquals, []string{filepath.Join(dir, "dm-master.log.1")})
+}
+
+func (t *testRotateSuite) TestScanRotatedLogs(c *C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "dm-worker.log")
+	testWriteGzip(c, filepath.Join(dir, "dm-worker-2021-11-17T10-00-00.log.gz"), `[2021/11/17 10:00:00.000 +00:00] [ERROR] [main.go:1] ["first"]`+"\n")
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "dm-worker-2021-11-18T10-00-00.log"), []byte(`[2021/11/18 10:00:00.000 +00:00] [ERROR] [main.go:2] ["second"]`+"\n"), 0o644), IsNil)
+	c.Assert(ioutil.WriteFile(path, []byte(`[2021/11/19 10:00:00.000 +00:00] [ERROR] [main.go:3] ["third"]`+"\n"), 0o644), IsNil)
+
+	ls, err := NewLogScanner(path)
+	c.Assert(err, IsNil)
+	for _, msg := range []string{`"first"`, `"second"`, `"third"`} {
+		l, err := ls.Scan()
+		c.Assert(err, IsNil)
+		c.Assert(l.Msg, Equals, msg)
+		c.Assert(l.LogPath, Equals, path)
+	}
+	_, err = ls.Scan()
+	c.Assert(err, Equals, io.EOF)
+}
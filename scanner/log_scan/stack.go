@@ -0,0 +1,73 @@
+package scanner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrorVerboseKey is the log field that pingcap/errors uses to carry the error stack trace
+const ErrorVerboseKey = "errorVerbose"
+
+// StackFrame is a frame of Go stack trace
+type StackFrame struct {
+	// Function is the function name with package path,
+	// e.g. github.com/pingcap/ticdc/dm/dm/worker.(*SourceWorker).updateSourceStatus
+	Function string
+	// File is the source file path, e.g. github.com/pingcap/ticdc/dm/dm/worker/source_worker.go
+	File string
+	Line int
+}
+
+func (f *StackFrame) String() string {
+	return fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line)
+}
+
+// StackTrace returns the frames of the stack trace in errorVerbose field, or nil if there is no stack trace
+func (l *Log) StackTrace() []*StackFrame {
+	verbose, ok := l.Fields[ErrorVerboseKey]
+	if !ok {
+		return nil
+	}
+	return ParseStackTrace(verbose)
+}
+
+// ParseStackTrace parses Go stack trace that printed by pkg/errors, e.g.
+//
+//	[code=11011:class=functional:scope=internal:level=high], Message: 0-1-7195 is not mysql GTID set
+//	github.com/pingcap/ticdc/dm/pkg/terror.(*Error).Generate
+//		github.com/pingcap/ticdc/dm/pkg/terror/terror.go:267
+//
+// the lines that are not a `function\n\tfile:line` pair are skipped
+func ParseStackTrace(stack string) []*StackFrame {
+	var frames []*StackFrame
+	lines := strings.Split(stack, "\n")
+	for i := 0; i+1 < len(lines); i++ {
+		fn, loc := lines[i], lines[i+1]
+		if fn == "" || strings.HasPrefix(fn, "\t") || !strings.HasPrefix(loc, "\t") {
+			continue
+		}
+
+		pos := strings.LastIndexByte(loc, ':')
+		if pos < 0 {
+			continue
+		}
+		// the location may be followed by pc offset, e.g. file.go:12 +0x1d
+		lineStr := loc[pos+1:]
+		if space := strings.IndexByte(lineStr, ' '); space >= 0 {
+			lineStr = lineStr[:space]
+		}
+		line, err := strconv.Atoi(lineStr)
+		if err != nil {
+			continue
+		}
+
+		frames = append(frames, &StackFrame{
+			Function: fn,
+			File:     strings.TrimPrefix(loc[:pos], "\t"),
+			Line:     line,
+		})
+		i++
+	}
+	return frames
+}
@@ -0,0 +1,40 @@
+package scanner
+
+import (
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testStackSuite{})
+
+type testStackSuite struct {
+}
+
+func (t *testStackSuite) TestParseStackTrace(c *C) {
+	_, logs := testGenerateStandardZapLogs()
+
+	c.Assert(logs[0].StackTrace(), IsNil)
+
+	frames := logs[2].StackTrace()
+	c.Assert(frames, HasLen, 11)
+	c.Assert(frames[0], DeepEquals, &StackFrame{
+		Function: "github.com/pingcap/ticdc/dm/pkg/terror.(*Error).Generate",
+		File:     "github.com/pingcap/ticdc/dm/pkg/terror/terror.go",
+		Line:     267,
+	})
+	c.Assert(frames[3], DeepEquals, &StackFrame{
+		Function: "github.com/pingcap/ticdc/dm/dm/worker.(*SourceWorker).updateSourceStatus",
+		File:     "github.com/pingcap/ticdc/dm/dm/worker/source_worker.go",
+		Line:     251,
+	})
+	c.Assert(frames[10], DeepEquals, &StackFrame{
+		Function: "runtime.goexit",
+		File:     "runtime/asm_amd64.s",
+		Line:     1371,
+	})
+
+	// goroutine dump style with pc offset, and broken frames
+	frames = ParseStackTrace("oops\nmain.main()\n\t/go/src/main.go:12 +0x1d\nbroken\n\tno-line\n")
+	c.Assert(frames, DeepEquals, []*StackFrame{
+		{Function: "main.main()", File: "/go/src/main.go", Line: 12},
+	})
+}
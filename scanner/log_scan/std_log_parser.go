@@ -0,0 +1,106 @@
+package scanner
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// stdLogHeaderRegexp matches the header written by the flags Ldate, Ltime, Lmicroseconds, Llongfile and Lshortfile,
+// e.g. 2021/11/18 23:20:53.596123 /go/src/dm/worker/source_worker.go:605:
+var stdLogHeaderRegexp = regexp.MustCompile(`^(?:(\d{4}/\d{2}/\d{2}) )?(?:(\d{2}:\d{2}:\d{2}(?:\.\d{6})?) )?(?:(\S+\.go:\d+): )?`)
+
+// stdLogMaxPrefixLen is the max length of the prefix before the header
+const stdLogMaxPrefixLen = 64
+
+// stdLogLevelPrefixes are the conventional level prefixes of messages, e.g. [error] failed to ...
+var stdLogLevelPrefixes = []struct {
+	prefix, level string
+}{
+	{"[error]", "error"}, {"error:", "error"},
+	{"[warn]", "warn"}, {"[warning]", "warn"}, {"warn:", "warn"}, {"warning:", "warn"},
+	{"[info]", "info"}, {"info:", "info"},
+	{"[fatal]", "fatal"}, {"fatal:", "fatal"},
+	{"[debug]", "debug"}, {"debug:", "debug"},
+}
+
+// https://pkg.go.dev/log, the log printed by the standard library log package, e.g.
+//
+//	2021/11/18 23:20:53 source_worker.go:605: failed to update source status
+//
+// the header may be any combination of the flags, LUTC only changes the time zone of the header.
+// The prefix set by log.SetPrefix is written before the header, or before the message with Lmsgprefix,
+// the unknown prefix before the header is skipped if it ends with a space, and the configured prefix is stripped at both places.
+// Logs without header are not accepted because any line would be suitable then
+type stdLogParser struct {
+	prefix string
+}
+
+// NewStdLogParser returns a standard library log parser, prefix is the prefix set by log.SetPrefix if any
+func NewStdLogParser(prefix string) LogParser {
+	return &stdLogParser{prefix: prefix}
+}
+
+func (s *stdLogParser) IsSuitable(content []byte) bool {
+	_, err := s.Parse(content)
+	return err == nil
+}
+
+func (s *stdLogParser) Parse(content []byte) (*Log, error) {
+	content = bytes.TrimRight(content, "\r\n")
+	if s.prefix != "" {
+		content = bytes.TrimPrefix(content, []byte(s.prefix))
+	}
+
+	var match []int
+	for i := 0; i <= stdLogMaxPrefixLen && i < len(content); i++ {
+		// the prefix is separated from the header by a space, e.g. [worker] 2021/11/18 ...,
+		// otherwise the timestamp of other formats would be taken as the header, e.g. [2021/11/18 23:20:53.596 +00:00]
+		if i > 0 && content[i-1] != ' ' {
+			continue
+		}
+		if m := stdLogHeaderRegexp.FindSubmatchIndex(content[i:]); m != nil && m[1] > 0 {
+			match = m
+			content = content[i:]
+			break
+		}
+	}
+	if match == nil {
+		return nil, ErrNeedSkipLog
+	}
+
+	l := &Log{}
+	var timestamp []string
+	for _, group := range []int{1, 2} {
+		if match[2*group] >= 0 {
+			timestamp = append(timestamp, string(content[match[2*group]:match[2*group+1]]))
+		}
+	}
+	l.Time = strings.Join(timestamp, " ")
+	if match[6] >= 0 {
+		// log patterns are matched by the file name and line number, e.g. source_worker.go:605
+		l.Position = filepath.Base(string(content[match[6]:match[7]]))
+	}
+
+	msg := string(content[match[1]:])
+	if s.prefix != "" {
+		msg = strings.TrimPrefix(msg, s.prefix)
+	}
+	l.Level = stdLogLevel(msg)
+	// the signatures of log patterns are quoted
+	l.Msg = strconv.Quote(msg)
+	return l, nil
+}
+
+// stdLogLevel infers the log level by the conventional prefix of message, it's empty if there is no such prefix
+func stdLogLevel(msg string) string {
+	lower := strings.ToLower(msg)
+	for _, p := range stdLogLevelPrefixes {
+		if strings.HasPrefix(lower, p.prefix) {
+			return p.level
+		}
+	}
+	return ""
+}
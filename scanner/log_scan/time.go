@@ -0,0 +1,41 @@
+package scanner
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logTimeFormats are the time formats of the supported logs, the fractional seconds are parsed even if they are not in format
+var logTimeFormats = []string{
+	// zap, e.g. 2021/11/18 23:20:53.596 +00:00
+	"2006/01/02 15:04:05 -07:00",
+	// the standard library log package, e.g. 2021/11/18 23:20:53
+	"2006/01/02 15:04:05",
+	// env_logger and JSON logs, e.g. 2021-11-18T23:21:56.901Z
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	// e.g. 2021-11-18 23:20:53,596
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05",
+}
+
+// ParseTime parses the time of log, the unix timestamps in seconds are supported too, e.g. 1637277716.901.
+// The time without zone is in local time zone, like the time printed by the standard library log package
+func (l *Log) ParseTime() (time.Time, bool) {
+	s := strings.TrimSpace(l.Time)
+	if s == "" {
+		return time.Time{}, false
+	}
+
+	for _, format := range logTimeFormats {
+		if t, err := time.ParseInLocation(format, s, time.Local); err == nil {
+			return t, true
+		}
+	}
+
+	if sec, err := strconv.ParseFloat(s, 64); err == nil && sec > 0 {
+		return time.Unix(0, int64(sec*float64(time.Second))), true
+	}
+	return time.Time{}, false
+}
@@ -13,6 +13,8 @@ import (
 type Payload struct {
 	log     *scanner.Log
 	pattern *logpattern_go_proto.LogPattern
+	// checkpoint is the checkpoint after the log, it's nil if the log can't be resumed
+	checkpoint *scanner.Checkpoint
 }
 
 // Pipeline used pass messages between processing units (log scan, log match and record)
@@ -4,7 +4,9 @@ import (
 	"context"
 	"io"
 	"log"
+	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/IANTHEREAL/logutil/pkg/util"
 	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
@@ -22,12 +24,114 @@ type LogProcessor struct {
 
 	matcher    *matcher.PatternMatcher
 	scannerSet []*scanner.LogScanner
+	lines      []*assemLine
 	coverager  *recorder.Coverager
 
 	unknowLogs *recorder.UnknowLogRecord
+	errCodes   *recorder.ErrorCodeRecorder
+	errPaths   *recorder.ErrorPathRecorder
+
+	skippedMu sync.Mutex
+	skipped   []*SkippedLog
+
+	// flushInterval is the interval of flushing coverage while running, the coverage is only flushed
+	// after all logs are scanned if it's zero
+	flushInterval time.Duration
+	// flushMu is held exclusively while flushing, and shared while recording a log and its checkpoint,
+	// so that the flushed coverage and checkpoints are consistent
+	flushMu sync.RWMutex
+}
+
+// followInterval is the interval of checking the followed log files for new lines
+const followInterval = 200 * time.Millisecond
+
+// SkippedLog is a log file that isn't scanned, e.g. a binary file or a file that no log parser is suitable for
+type SkippedLog struct {
+	Path   string
+	Reason string
 }
 
+// NewLogProcessor returns a log processor of the log files, the files that can't be scanned are skipped
+// and reported by Skipped
 func NewLogProcessor(store *keyvalue.Store, logPaths []string) (*LogProcessor, error) {
+	// the rotated backups are read with their log files, so they are skipped if their log files are scanned too
+	var skipped []*SkippedLog
+	rotated := make(map[string]struct{})
+	unreadable := make(map[string]struct{})
+	for _, path := range logPaths {
+		if scanner.IsArchive(path) {
+			continue
+		}
+		files, err := scanner.RotatedLogFiles(path)
+		if err != nil {
+			skipped = append(skipped, &SkippedLog{Path: path, Reason: err.Error()})
+			unreadable[path] = struct{}{}
+			continue
+		}
+		for _, file := range files {
+			if file != path {
+				rotated[filepath.Clean(file)] = struct{}{}
+			}
+		}
+	}
+
+	scannerSet := make([]*scanner.LogScanner, 0, len(logPaths))
+	for _, path := range logPaths {
+		if _, ok := unreadable[path]; ok {
+			continue
+		}
+		if _, ok := rotated[filepath.Clean(path)]; ok {
+			log.Printf("skip rotated log %s that is read with its log file", path)
+			continue
+		}
+		newScanner := scanner.NewLogScanner
+		if scanner.IsArchive(path) {
+			newScanner = scanner.NewArchiveLogScanner
+		}
+		if !scanner.IsArchive(path) {
+			if binary, err := scanner.IsBinaryLog(path); err == nil && binary {
+				skipped = append(skipped, &SkippedLog{Path: path, Reason: "binary file"})
+				continue
+			}
+		}
+		scanner, err := newScanner(path)
+		if err != nil {
+			skipped = append(skipped, &SkippedLog{Path: path, Reason: err.Error()})
+			continue
+		}
+		scannerSet = append(scannerSet, scanner)
+	}
+
+	return newLogProcessor(store, scannerSet, skipped)
+}
+
+// NewFollowLogProcessor returns a log processor that follows the log files until ctx is done, the log is read from stdin
+// if the log path is scanner.StdinLogPath. The coverage is flushed into store every flushInterval while running
+func NewFollowLogProcessor(ctx context.Context, store *keyvalue.Store, logPaths []string, flushInterval time.Duration) (*LogProcessor, error) {
+	var skipped []*SkippedLog
+	scannerSet := make([]*scanner.LogScanner, 0, len(logPaths))
+	for _, path := range logPaths {
+		if scanner.IsArchive(path) {
+			skipped = append(skipped, &SkippedLog{Path: path, Reason: "archive can't be followed"})
+			continue
+		}
+		scanner, err := scanner.NewFollowLogScanner(ctx, path, followInterval)
+		if err != nil {
+			skipped = append(skipped, &SkippedLog{Path: path, Reason: err.Error()})
+			continue
+		}
+		scannerSet = append(scannerSet, scanner)
+	}
+
+	p, err := newLogProcessor(store, scannerSet, skipped)
+	if err != nil {
+		return nil, err
+	}
+	p.flushInterval = flushInterval
+	return p, nil
+}
+
+func newLogProcessor(store *keyvalue.Store, scannerSet []*scanner.LogScanner, skipped []*SkippedLog) (*LogProcessor, error) {
 	matcher, err := matcher.NewPatternMatcher(store)
 	if err != nil {
 		return nil, err
@@ -35,21 +139,38 @@ func NewLogProcessor(store *keyvalue.Store, logPaths []string) (*LogProcessor, e
 
 	coverager := recorder.NewCoverager(store)
 	unknowLogs := recorder.NewUnknowLogRecorder()
+	errCodes := recorder.NewErrorCodeRecorder(store)
+	errPaths, err := recorder.NewErrorPathRecorder(store)
+	if err != nil {
+		return nil, err
+	}
 
-	scannerSet := make([]*scanner.LogScanner, 0, len(logPaths))
-	for _, path := range logPaths {
-		scanner, err := scanner.NewLogScanner(path)
-		if err != nil {
-			return nil, err
+	// the logs are resumed from their checkpoints, so that only the new logs are added to the coverage in store
+	checkpoints, err := loadCheckpoints(store)
+	if err != nil {
+		return nil, err
+	}
+	resumed := scannerSet[:0]
+	for _, logScanner := range scannerSet {
+		if cp, ok := checkpoints[logScanner.GetLogPath()]; ok {
+			if err := logScanner.Resume(cp); err != nil {
+				skipped = append(skipped, &SkippedLog{Path: logScanner.GetLogPath(), Reason: err.Error()})
+				continue
+			}
 		}
-		scannerSet = append(scannerSet, scanner)
+		resumed = append(resumed, logScanner)
 	}
+	scannerSet = resumed
 
 	return &LogProcessor{
+		store:      store,
+		skipped:    skipped,
 		scannerSet: scannerSet,
 		coverager:  coverager,
 		matcher:    matcher,
 		unknowLogs: unknowLogs,
+		errCodes:   errCodes,
+		errPaths:   errPaths,
 	}, nil
 }
 
@@ -58,20 +179,135 @@ func (s *LogProcessor) Run(ctx context.Context, rule *logpattern_go_proto.LogPat
 	//pipeline := NewPipiline(1024)
 	wg := sync.WaitGroup{}
 
-	for _, scanner := range s.scannerSet {
-		line := newAssemLine(s.matcher, scanner, s.coverager, s.unknowLogs)
+	for _, logScanner := range s.scannerSet {
+		line := newAssemLine(s.matcher, logScanner, s.coverager, s.unknowLogs, s.errCodes, s.errPaths)
+		line.recordMu = &s.flushMu
+		s.lines = append(s.lines, line)
+	}
+	for _, line := range s.lines {
 		wg.Add(1)
 		go func(l *assemLine) {
-			if err := l.run(ctx, rule); err != nil {
+			if err := l.run(ctx, rule); err == scanner.ErrNotFoundParser {
+				s.skip(l.scanner.GetLogPath(), "no suitable log parser")
+			} else if err != nil {
 				log.Printf("process line %s meet error %v", l.scanner.GetLogPath(), err)
+			} else {
+				// the logs are all recorded, the checkpoint includes the skipped lines at the end
+				l.recordCheckpoint(l.scanner.Checkpoint())
 			}
 			wg.Done()
 		}(line)
 	}
 
+	stopFlush := make(chan struct{})
+	flushDone := make(chan struct{})
+	go func() {
+		s.runFlush(stopFlush)
+		close(flushDone)
+	}()
+
 	wg.Wait()
-	s.unknowLogs.Flush()
-	return s.coverager.Flush()
+	close(stopFlush)
+	<-flushDone
+
+	return s.flush()
+}
+
+// runFlush flushes the coverage into store every flushInterval until stop is closed,
+// so that the coverage can be analyzed while the logs are followed
+func (s *LogProcessor) runFlush(stop chan struct{}) {
+	if s.flushInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := s.flush(); err != nil {
+				log.Printf("flush coverage meets error %v", err)
+			}
+		}
+	}
+}
+
+// flush merges the coverage into store and writes the checkpoints of logs in one batch, and releases the store
+// so that other processes can read it. The recorded coverage is reset after the batch is committed
+func (s *LogProcessor) flush() error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	batch := s.store.Batch()
+	if err := s.errCodes.Flush(batch); err != nil {
+		return err
+	}
+	if err := s.errPaths.Flush(batch); err != nil {
+		return err
+	}
+	if err := s.coverager.Flush(batch); err != nil {
+		return err
+	}
+	if err := s.unknowLogs.Flush(batch); err != nil {
+		return err
+	}
+	for _, line := range s.lines {
+		if line.checkpoint == nil {
+			continue
+		}
+		cp := line.checkpoint
+		err := batch.WriteScanCheckpoint(context.Background(), &logpattern_go_proto.ScanCheckpoint{
+			LogPath:  line.scanner.GetLogPath(),
+			FilePath: cp.FilePath,
+			FileId:   cp.FileID,
+			Offset:   cp.Offset,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := batch.Commit(context.Background()); err != nil {
+		return err
+	}
+
+	s.errCodes.Reset()
+	s.errPaths.Reset()
+	s.coverager.Reset()
+	s.unknowLogs.Reset()
+	return s.store.Release()
+}
+
+// loadCheckpoints returns the checkpoints of logs in store by log path
+func loadCheckpoints(store *keyvalue.Store) (map[string]*scanner.Checkpoint, error) {
+	checkpoints := make(map[string]*scanner.Checkpoint)
+	err := store.ScanScanCheckpoint(context.Background(), func(_, value []byte) error {
+		cp := &logpattern_go_proto.ScanCheckpoint{}
+		if err := cp.Unmarshal(value); err != nil {
+			return err
+		}
+		checkpoints[cp.LogPath] = &scanner.Checkpoint{
+			FilePath: cp.FilePath,
+			FileID:   cp.FileId,
+			Offset:   cp.Offset,
+		}
+		return nil
+	})
+	return checkpoints, err
+}
+
+func (s *LogProcessor) skip(path, reason string) {
+	s.skippedMu.Lock()
+	defer s.skippedMu.Unlock()
+	s.skipped = append(s.skipped, &SkippedLog{Path: path, Reason: reason})
+}
+
+// Skipped returns the log files that are skipped, it should be called after Run
+func (s *LogProcessor) Skipped() []*SkippedLog {
+	s.skippedMu.Lock()
+	defer s.skippedMu.Unlock()
+	return append([]*SkippedLog{}, s.skipped...)
 }
 
 // assemLine is a separate logics processing flow,
@@ -81,14 +317,23 @@ type assemLine struct {
 	scanner    *scanner.LogScanner
 	coverager  *recorder.Coverager
 	unknowLogs *recorder.UnknowLogRecord
+	errCodes   *recorder.ErrorCodeRecorder
+	errPaths   *recorder.ErrorPathRecorder
+
+	// recordMu is shared while recording a log and its checkpoint
+	recordMu *sync.RWMutex
+	// checkpoint is the checkpoint after the last recorded log
+	checkpoint *scanner.Checkpoint
 }
 
-func newAssemLine(matcher *matcher.PatternMatcher, scanner *scanner.LogScanner, coverager *recorder.Coverager, unknowLogs *recorder.UnknowLogRecord) *assemLine {
+func newAssemLine(matcher *matcher.PatternMatcher, scanner *scanner.LogScanner, coverager *recorder.Coverager, unknowLogs *recorder.UnknowLogRecord, errCodes *recorder.ErrorCodeRecorder, errPaths *recorder.ErrorPathRecorder) *assemLine {
 	return &assemLine{
 		matcher:    matcher,
 		scanner:    scanner,
 		coverager:  coverager,
 		unknowLogs: unknowLogs,
+		errCodes:   errCodes,
+		errPaths:   errPaths,
 	}
 }
 
@@ -137,7 +382,7 @@ func (l *assemLine) runLogScan(ctx context.Context, pipeline *Pipeline) error {
 			return err
 		}
 
-		err = pipeline.Write(ctx, &Payload{log: lg})
+		err = pipeline.Write(ctx, &Payload{log: lg, checkpoint: l.scanner.Checkpoint()})
 		if err != nil {
 			return err
 		}
@@ -154,13 +399,31 @@ func (l *assemLine) runMathAndRecord(ctx context.Context, pipeline *Pipeline, ru
 			return err
 		}
 
+		l.recordMu.RLock()
+		l.errCodes.Record(payload.log)
+		l.errPaths.Record(payload.log)
+
 		res := l.matcher.Match(payload.log)
 		if (res == nil || len(res.Patterns) == 0) && util.MatchLogPatternRule(rule, payload.log.Level, "") {
 			l.unknowLogs.Record(payload.log)
 		} else {
 			for _, lp := range res.Patterns {
-				l.coverager.Record(payload.log, lp)
+				l.coverager.Record(payload.log, lp, len(res.Patterns) > 1)
 			}
 		}
+		if payload.checkpoint != nil {
+			l.checkpoint = payload.checkpoint
+		}
+		l.recordMu.RUnlock()
+	}
+}
+
+// recordCheckpoint records the checkpoint after the logs recorded
+func (l *assemLine) recordCheckpoint(cp *scanner.Checkpoint) {
+	if cp == nil {
+		return
 	}
+	l.recordMu.RLock()
+	l.checkpoint = cp
+	l.recordMu.RUnlock()
 }
@@ -0,0 +1,146 @@
+package log_scanner
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	logpattern_go_proto "github.com/IANTHEREAL/logutil/proto"
+	scanner "github.com/IANTHEREAL/logutil/scanner/log_scan"
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+	"github.com/IANTHEREAL/logutil/storage/leveldb"
+	. "github.com/pingcap/check"
+)
+
+func TestClient(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testLogProcessorSuite{})
+
+type testLogProcessorSuite struct {
+}
+
+// testFailedDB fails to write if failed is set, so that the flushing fails
+type testFailedDB struct {
+	keyvalue.DB
+	failed bool
+}
+
+func (db *testFailedDB) Writer(ctx context.Context) (keyvalue.Writer, error) {
+	if db.failed {
+		return nil, errors.New("injected write error")
+	}
+	return db.DB.Writer(ctx)
+}
+
+// testUnknowLogs returns the count of unknown logs in the store, the store is opened and closed meanwhile
+// like `logcov analyze`
+func testUnknowLogs(c *C, storePath string) int64 {
+	db, err := leveldb.Open(storePath, &leveldb.Options{LockTimeout: 10 * time.Second})
+	c.Assert(err, IsNil)
+	defer db.Close(context.Background())
+	return testCountUnknowLogs(c, keyvalue.NewLogPatternStore(db))
+}
+
+func testCountUnknowLogs(c *C, store *keyvalue.Store) int64 {
+	var count int64
+	err := store.ScanUnknowLog(context.Background(), func(_, value []byte) error {
+		lp := &logpattern_go_proto.UnknowLogPattern{}
+		if err := lp.Unmarshal(value); err != nil {
+			return err
+		}
+		count += lp.CovCount
+		return nil
+	})
+	c.Assert(err, IsNil)
+	return count
+}
+
+func (t *testLogProcessorSuite) TestFollowFlush(c *C) {
+	dir := c.MkDir()
+	logPath := filepath.Join(dir, "dm-worker.log")
+	c.Assert(ioutil.WriteFile(logPath, []byte(`[2021/11/17 10:00:00.000 +00:00] [ERROR] [main.go:1] ["connect failed"]`+"\n"), 0o644), IsNil)
+
+	storePath := filepath.Join(dir, "store")
+	db, err := leveldb.OpenReleasable(storePath, &leveldb.Options{LockTimeout: 10 * time.Second})
+	c.Assert(err, IsNil)
+	store := keyvalue.NewLogPatternStore(db)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p, err := NewFollowLogProcessor(ctx, store, []string{logPath}, 50*time.Millisecond)
+	c.Assert(err, IsNil)
+	c.Assert(store.Release(), IsNil)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- p.Run(context.Background(), nil)
+	}()
+
+	// the coverage is flushed and the store is released while following
+	deadline := time.Now().Add(10 * time.Second)
+	for testUnknowLogs(c, storePath) == 0 {
+		c.Assert(time.Now().Before(deadline), IsTrue)
+		time.Sleep(50 * time.Millisecond)
+	}
+	c.Assert(testUnknowLogs(c, storePath), Equals, int64(1))
+
+	// the flushed coverage isn't flushed again
+	time.Sleep(200 * time.Millisecond)
+	c.Assert(testUnknowLogs(c, storePath), Equals, int64(1))
+
+	cancel()
+	c.Assert(<-done, IsNil)
+	c.Assert(testUnknowLogs(c, storePath), Equals, int64(1))
+	c.Assert(p.Skipped(), HasLen, 0)
+}
+
+func (t *testLogProcessorSuite) TestFlushFailed(c *C) {
+	db, err := leveldb.Open(c.MkDir(), nil)
+	c.Assert(err, IsNil)
+	failedDB := &testFailedDB{DB: db}
+	store := keyvalue.NewLogPatternStore(failedDB)
+	p, err := newLogProcessor(store, nil, nil)
+	c.Assert(err, IsNil)
+
+	p.unknowLogs.Record(&scanner.Log{LogPath: "dm-worker.log", Level: "error", Position: "main.go:1", Msg: `"exit"`})
+	p.errCodes.Record(&scanner.Log{LogPath: "dm-worker.log", Fields: map[string]string{"error": "[code=10001:class=database:scope=downstream:level=high]"}})
+
+	// the recorded coverage is kept if the batch fails to commit, and it's flushed next time
+	failedDB.failed = true
+	c.Assert(p.flush(), ErrorMatches, ".*injected write error")
+	c.Assert(testCountUnknowLogs(c, store), Equals, int64(0))
+	failedDB.failed = false
+	c.Assert(p.flush(), IsNil)
+	c.Assert(testCountUnknowLogs(c, store), Equals, int64(1))
+	cov, err := store.GetErrorCodeCoverage(context.Background(), 10001)
+	c.Assert(err, IsNil)
+	c.Assert(cov.CovCount, Equals, int64(1))
+
+	// the flushed coverage is reset
+	c.Assert(p.flush(), IsNil)
+	c.Assert(testCountUnknowLogs(c, store), Equals, int64(1))
+}
+
+func (t *testLogProcessorSuite) TestSkipUnreadableLog(c *C) {
+	dir := c.MkDir()
+	logPath := filepath.Join(dir, "dm-worker.log")
+	c.Assert(ioutil.WriteFile(logPath, []byte(`[2021/11/17 10:00:00.000 +00:00] [ERROR] [main.go:1] ["connect failed"]`+"\n"), 0o644), IsNil)
+	db, err := leveldb.Open(filepath.Join(dir, "store"), nil)
+	c.Assert(err, IsNil)
+	defer db.Close(context.Background())
+
+	// the rotated backups of log in missing directory can't be listed, the log is skipped and the others are scanned
+	missing := filepath.Join(dir, "missing", "dm-master.log")
+	p, err := NewLogProcessor(keyvalue.NewLogPatternStore(db), []string{missing, logPath})
+	c.Assert(err, IsNil)
+	c.Assert(p.Skipped(), HasLen, 1)
+	c.Assert(p.Skipped()[0].Path, Equals, missing)
+	c.Assert(p.Skipped()[0].Reason, Matches, ".*no such file or directory")
+	c.Assert(p.scannerSet, HasLen, 1)
+	c.Assert(p.scannerSet[0].GetLogPath(), Equals, logPath)
+}
@@ -3,6 +3,7 @@ package keyvalue
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
@@ -30,6 +31,14 @@ const (
 // A Store implements the log pattern store to persist data such as log pattern and coverage
 type Store struct {
 	db DB
+
+	// batch collects the writes if the store is returned by Batch
+	batch *batch
+}
+
+// batch is the writes that are applied atomically
+type batch struct {
+	keys, values [][]byte
 }
 
 // NewLogPatternStore returns a log pattern store backed by the given keyvalue DB.
@@ -67,6 +76,12 @@ type DB interface {
 	Close(context.Context) error
 }
 
+// Releaser is implemented by the DBs that release the underlying database between uses,
+// so that the database can be opened by other processes meanwhile
+type Releaser interface {
+	Release() error
+}
+
 // Iterator provides sequential access to a DB. Iterators must be Closed when
 // no longer used to ensure that resources are not leaked.
 type Iterator interface {
@@ -92,6 +107,10 @@ type Writer interface {
 	// Write writes a key-value entry to the DB. Writes may be batched until the
 	// Writer is Closed.
 	Write(key, val []byte) error
+
+	// Delete deletes the key-value entry of key from the DB. Deletes may be
+	// batched with writes until the Writer is Closed.
+	Delete(key []byte) error
 }
 
 // WritePool is a wrapper around a DB that automatically creates and flushes
@@ -202,6 +221,24 @@ func (s *Store) WriteLogCoverage(ctx context.Context, coverage *logpattern_go_pr
 	return s.write(ctx, key, value)
 }
 
+// GetLogCoverage returns the coverage data of log pattern at position, it returns nil if there is no coverage.
+func (s *Store) GetLogCoverage(ctx context.Context, pos *logpattern_go_proto.Position) (*logpattern_go_proto.Coverage, error) {
+	key, err := EncodeCoverageKey(pos)
+	if err != nil {
+		return nil, fmt.Errorf("encoding error: %v", err)
+	}
+
+	value, err := s.get(ctx, key)
+	if value == nil || err != nil {
+		return nil, err
+	}
+	coverage := &logpattern_go_proto.Coverage{}
+	if err := coverage.Unmarshal(value); err != nil {
+		return nil, fmt.Errorf("decoding error: %v", err)
+	}
+	return coverage, nil
+}
+
 // ScanLogCoverage scans all log coverage from the keyvalue DB.
 func (s *Store) ScanLogCoverage(ctx context.Context, fn func(key, value []byte) error) error {
 	return s.scan(ctx, coverageKeyPrefixBytes, fn)
@@ -223,7 +260,283 @@ func (s *Store) ScanLogPatternRule(ctx context.Context, fn func(key, value []byt
 	return s.scan(ctx, patternRuleKeyPrefixBytes, fn)
 }
 
+// WriteFunction used write function entity into keyvalue DB.
+func (s *Store) WriteFunction(ctx context.Context, fn *logpattern_go_proto.FuncInfo) (err error) {
+	key, err := EncodeFunctionKey(fn.Pos)
+	if err != nil {
+		return fmt.Errorf("encoding error: %v", err)
+	}
+
+	value, err := fn.Marshal()
+	if err != nil {
+		return fmt.Errorf("encoding error: %v", err)
+	}
+	return s.write(ctx, key, value)
+}
+
+// ScanFunction scans all functions from the keyvalue DB.
+func (s *Store) ScanFunction(ctx context.Context, fn func(key, value []byte) error) error {
+	return s.scan(ctx, functionKeyPrefixBytes, fn)
+}
+
+// WriteErrorPathCoverage used write error path coverage data of function into keyvalue DB.
+func (s *Store) WriteErrorPathCoverage(ctx context.Context, coverage *logpattern_go_proto.ErrorPathCoverage) (err error) {
+	key, err := EncodeErrorPathCoverageKey(coverage.Pos)
+	if err != nil {
+		return fmt.Errorf("encoding error: %v", err)
+	}
+
+	value, err := coverage.Marshal()
+	if err != nil {
+		return fmt.Errorf("encoding error: %v", err)
+	}
+	return s.write(ctx, key, value)
+}
+
+// GetErrorPathCoverage returns the error path coverage data of function at position, it returns nil if there is no coverage.
+func (s *Store) GetErrorPathCoverage(ctx context.Context, pos *logpattern_go_proto.Position) (*logpattern_go_proto.ErrorPathCoverage, error) {
+	key, err := EncodeErrorPathCoverageKey(pos)
+	if err != nil {
+		return nil, fmt.Errorf("encoding error: %v", err)
+	}
+
+	value, err := s.get(ctx, key)
+	if value == nil || err != nil {
+		return nil, err
+	}
+	coverage := &logpattern_go_proto.ErrorPathCoverage{}
+	if err := coverage.Unmarshal(value); err != nil {
+		return nil, fmt.Errorf("decoding error: %v", err)
+	}
+	return coverage, nil
+}
+
+// ScanErrorPathCoverage scans all error path coverage from the keyvalue DB.
+func (s *Store) ScanErrorPathCoverage(ctx context.Context, fn func(key, value []byte) error) error {
+	return s.scan(ctx, errorPathCoverageKeyPrefixBytes, fn)
+}
+
+// WriteErrorCode used write error code entity into keyvalue DB.
+func (s *Store) WriteErrorCode(ctx context.Context, code *logpattern_go_proto.ErrorCode) (err error) {
+	key, _ := EncodeErrorCodeKey(code.Code)
+
+	value, err := code.Marshal()
+	if err != nil {
+		return fmt.Errorf("encoding error: %v", err)
+	}
+	return s.write(ctx, key, value)
+}
+
+// ScanErrorCode scans all error codes from the keyvalue DB.
+func (s *Store) ScanErrorCode(ctx context.Context, fn func(key, value []byte) error) error {
+	return s.scan(ctx, errorCodeKeyPrefixBytes, fn)
+}
+
+// WriteErrorCodeCoverage used write error code coverage data into keyvalue DB.
+func (s *Store) WriteErrorCodeCoverage(ctx context.Context, coverage *logpattern_go_proto.ErrorCodeCoverage) (err error) {
+	key, _ := EncodeErrorCodeCoverageKey(coverage.Code)
+
+	value, err := coverage.Marshal()
+	if err != nil {
+		return fmt.Errorf("encoding error: %v", err)
+	}
+	return s.write(ctx, key, value)
+}
+
+// GetErrorCodeCoverage returns the coverage data of error code, it returns nil if there is no coverage.
+func (s *Store) GetErrorCodeCoverage(ctx context.Context, code int32) (*logpattern_go_proto.ErrorCodeCoverage, error) {
+	key, _ := EncodeErrorCodeCoverageKey(code)
+
+	value, err := s.get(ctx, key)
+	if value == nil || err != nil {
+		return nil, err
+	}
+	coverage := &logpattern_go_proto.ErrorCodeCoverage{}
+	if err := coverage.Unmarshal(value); err != nil {
+		return nil, fmt.Errorf("decoding error: %v", err)
+	}
+	return coverage, nil
+}
+
+// ScanErrorCodeCoverage scans all error code coverage from the keyvalue DB.
+func (s *Store) ScanErrorCodeCoverage(ctx context.Context, fn func(key, value []byte) error) error {
+	return s.scan(ctx, errorCodeCoverageKeyPrefixBytes, fn)
+}
+
+// WriteSilentErrorPath used write silent error path entity into keyvalue DB.
+func (s *Store) WriteSilentErrorPath(ctx context.Context, path *logpattern_go_proto.SilentErrorPath) (err error) {
+	key, err := EncodeSilentErrorPathKey(path.Pos)
+	if err != nil {
+		return fmt.Errorf("encoding error: %v", err)
+	}
+
+	value, err := path.Marshal()
+	if err != nil {
+		return fmt.Errorf("encoding error: %v", err)
+	}
+	return s.write(ctx, key, value)
+}
+
+// ScanSilentErrorPath scans all silent error paths from the keyvalue DB.
+func (s *Store) ScanSilentErrorPath(ctx context.Context, fn func(key, value []byte) error) error {
+	return s.scan(ctx, silentErrorPathKeyPrefixBytes, fn)
+}
+
+// WriteScanCheckpoint used write scan checkpoint of log into keyvalue DB.
+func (s *Store) WriteScanCheckpoint(ctx context.Context, cp *logpattern_go_proto.ScanCheckpoint) (err error) {
+	key, _ := EncodeScanCheckpointKey(cp.LogPath)
+
+	value, err := cp.Marshal()
+	if err != nil {
+		return fmt.Errorf("encoding error: %v", err)
+	}
+	return s.write(ctx, key, value)
+}
+
+// ScanScanCheckpoint scans all scan checkpoints from the keyvalue DB.
+func (s *Store) ScanScanCheckpoint(ctx context.Context, fn func(key, value []byte) error) error {
+	return s.scan(ctx, scanCheckpointKeyPrefixBytes, fn)
+}
+
+// WriteUnknowLog used write the log that is not captured by log extractor into keyvalue DB.
+func (s *Store) WriteUnknowLog(ctx context.Context, unknow *logpattern_go_proto.UnknowLogPattern) (err error) {
+	key, err := EncodeUnknowLogKey(unknow)
+	if err != nil {
+		return fmt.Errorf("encoding error: %v", err)
+	}
+
+	value, err := unknow.Marshal()
+	if err != nil {
+		return fmt.Errorf("encoding error: %v", err)
+	}
+	return s.write(ctx, key, value)
+}
+
+// GetUnknowLog returns the unknow log of the same level, position and message, it returns nil if there is no such log.
+func (s *Store) GetUnknowLog(ctx context.Context, unknow *logpattern_go_proto.UnknowLogPattern) (*logpattern_go_proto.UnknowLogPattern, error) {
+	key, err := EncodeUnknowLogKey(unknow)
+	if err != nil {
+		return nil, fmt.Errorf("encoding error: %v", err)
+	}
+
+	value, err := s.get(ctx, key)
+	if value == nil || err != nil {
+		return nil, err
+	}
+	stored := &logpattern_go_proto.UnknowLogPattern{}
+	if err := stored.Unmarshal(value); err != nil {
+		return nil, fmt.Errorf("decoding error: %v", err)
+	}
+	return stored, nil
+}
+
+// ScanUnknowLog scans all unknow logs from the keyvalue DB.
+func (s *Store) ScanUnknowLog(ctx context.Context, fn func(key, value []byte) error) error {
+	return s.scan(ctx, unknowLogKeyPrefixBytes, fn)
+}
+
+// ResetCoverage deletes the log coverage, error code coverage, error path coverage, unknow logs and scan checkpoints
+// from the keyvalue DB, so that the logs are scanned as the first time.
+func (s *Store) ResetCoverage(ctx context.Context) (err error) {
+	var keys [][]byte
+	for _, prefix := range [][]byte{
+		coverageKeyPrefixBytes,
+		errorCodeCoverageKeyPrefixBytes,
+		errorPathCoverageKeyPrefixBytes,
+		unknowLogKeyPrefixBytes,
+		scanCheckpointKeyPrefixBytes,
+	} {
+		err := s.scan(ctx, prefix, func(key, _ []byte) error {
+			keys = append(keys, append([]byte{}, key...))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	wr, err := s.db.Writer(ctx)
+	if err != nil {
+		return fmt.Errorf("db writer error: %v", err)
+	}
+	defer func() {
+		cErr := wr.Close()
+		if err == nil && cErr != nil {
+			err = fmt.Errorf("db writer close error: %v", cErr)
+		}
+	}()
+
+	for _, key := range keys {
+		if err := wr.Delete(key); err != nil {
+			return fmt.Errorf("db delete error: %v", err)
+		}
+	}
+	return nil
+}
+
+// CopyTo copies the key-value entries of the key prefixes into dst, e.g. the log patterns of LogPatternKeyPrefix.
+func (s *Store) CopyTo(ctx context.Context, dst *Store, prefixes ...string) error {
+	for _, prefix := range prefixes {
+		err := s.scan(ctx, []byte(prefix), func(key, value []byte) error {
+			return dst.write(ctx, append([]byte{}, key...), append([]byte{}, value...))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Batch returns a store whose writes are collected instead of written into the keyvalue DB,
+// the writes are applied atomically by Commit. The reads of the returned store don't see the collected writes.
+func (s *Store) Batch() *Store {
+	return &Store{db: s.db, batch: &batch{}}
+}
+
+// Commit applies the writes collected by the store returned by Batch.
+func (s *Store) Commit(ctx context.Context) (err error) {
+	if s.batch == nil {
+		return errors.New("commit the store that isn't a batch")
+	}
+	keys, values := s.batch.keys, s.batch.values
+	s.batch.keys, s.batch.values = nil, nil
+
+	wr, err := s.db.Writer(ctx)
+	if err != nil {
+		return fmt.Errorf("db writer error: %v", err)
+	}
+	defer func() {
+		cErr := wr.Close()
+		if err == nil && cErr != nil {
+			err = fmt.Errorf("db writer close error: %v", cErr)
+		}
+	}()
+
+	for i := range keys {
+		if err := wr.Write(keys[i], values[i]); err != nil {
+			return fmt.Errorf("db write error: %v", err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) get(ctx context.Context, key []byte) ([]byte, error) {
+	value, err := s.db.Get(ctx, key, &Options{})
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("db get error: %v", err)
+	}
+	return value, nil
+}
+
 func (s *Store) write(ctx context.Context, key, value []byte) (err error) {
+	if s.batch != nil {
+		s.batch.keys = append(s.batch.keys, key)
+		s.batch.values = append(s.batch.values, value)
+		return nil
+	}
+
 	wr, err := s.db.Writer(ctx)
 	if err != nil {
 		return fmt.Errorf("db writer error: %v", err)
@@ -262,14 +575,28 @@ func (s *Store) scan(ctx context.Context, keyPrefix []byte, fn func(key, value [
 	return nil
 }
 
+// Release releases the underlying database if the DB is a Releaser, the database is opened again on next use
+func (s *Store) Release() error {
+	if r, ok := s.db.(Releaser); ok {
+		return r.Release()
+	}
+	return nil
+}
+
 // Close implements part of the graphstore.Service interface.
 func (s *Store) Close(ctx context.Context) error { return s.db.Close(ctx) }
 
 const (
-	LogPatternKeyPrefix     = "log:"
-	FunctionKeyPrefix       = "fn:"
-	CoverageKeyPrefix       = "cov:"
-	LogPatternRuleKeyPrefix = "rule:"
+	LogPatternKeyPrefix      = "log:"
+	FunctionKeyPrefix        = "fn:"
+	CoverageKeyPrefix        = "cov:"
+	LogPatternRuleKeyPrefix  = "rule:"
+	ErrorCodeKeyPrefix       = "errcode:"
+	ErrorCodeCovKeyPrefix    = "errcov:"
+	ErrorPathCovKeyPrefix    = "errpath:"
+	SilentErrorPathKeyPrefix = "silent:"
+	ScanCheckpointKeyPrefix  = "ckpt:"
+	UnknowLogKeyPrefix       = "unknow:"
 )
 
 var (
@@ -277,6 +604,13 @@ var (
 	functionKeyPrefixBytes    = []byte(FunctionKeyPrefix)
 	coverageKeyPrefixBytes    = []byte(CoverageKeyPrefix)
 	patternRuleKeyPrefixBytes = []byte(LogPatternRuleKeyPrefix)
+
+	errorCodeKeyPrefixBytes         = []byte(ErrorCodeKeyPrefix)
+	errorCodeCoverageKeyPrefixBytes = []byte(ErrorCodeCovKeyPrefix)
+	errorPathCoverageKeyPrefixBytes = []byte(ErrorPathCovKeyPrefix)
+	silentErrorPathKeyPrefixBytes   = []byte(SilentErrorPathKeyPrefix)
+	scanCheckpointKeyPrefixBytes    = []byte(ScanCheckpointKeyPrefix)
+	unknowLogKeyPrefixBytes         = []byte(UnknowLogKeyPrefix)
 )
 
 // EncodeLogKey returns a canonical encoding key of log pattern
@@ -313,6 +647,74 @@ func EncodeCoverageKey(pos *logpattern_go_proto.Position) ([]byte, error) {
 	}, nil), nil
 }
 
+// EncodeFunctionKey returns a canonical encoding key of function
+func EncodeFunctionKey(pos *logpattern_go_proto.Position) ([]byte, error) {
+	if pos == nil {
+		return nil, errors.New("invalid position: missing position for key encoding")
+	}
+
+	posBytes, err := pos.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.Join([][]byte{
+		functionKeyPrefixBytes,
+		posBytes,
+	}, nil), nil
+}
+
+// EncodeErrorPathCoverageKey returns a canonical encoding key of error path coverage data
+func EncodeErrorPathCoverageKey(pos *logpattern_go_proto.Position) ([]byte, error) {
+	if pos == nil {
+		return nil, errors.New("invalid position: missing position for key encoding")
+	}
+
+	posBytes, err := pos.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.Join([][]byte{
+		errorPathCoverageKeyPrefixBytes,
+		posBytes,
+	}, nil), nil
+}
+
+// EncodeSilentErrorPathKey returns a canonical encoding key of silent error path
+func EncodeSilentErrorPathKey(pos *logpattern_go_proto.Position) ([]byte, error) {
+	if pos == nil {
+		return nil, errors.New("invalid position: missing position for key encoding")
+	}
+
+	posBytes, err := pos.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.Join([][]byte{
+		silentErrorPathKeyPrefixBytes,
+		posBytes,
+	}, nil), nil
+}
+
+// EncodeUnknowLogKey returns a canonical encoding key of unknow log by its level, position and message
+func EncodeUnknowLogKey(unknow *logpattern_go_proto.UnknowLogPattern) ([]byte, error) {
+	if unknow.Pos == nil {
+		return nil, errors.New("invalid position: missing position for key encoding")
+	}
+
+	idBytes, err := (&logpattern_go_proto.UnknowLogPattern{Pos: unknow.Pos, Level: unknow.Level, Msg: unknow.Msg}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.Join([][]byte{
+		unknowLogKeyPrefixBytes,
+		idBytes,
+	}, nil), nil
+}
+
 // EncodeLogPatternRuleKey returns a canonical encoding key of log pattern rule
 func EncodeLogPatternRuleKey() ([]byte, error) {
 	return bytes.Join([][]byte{
@@ -320,3 +722,34 @@ func EncodeLogPatternRuleKey() ([]byte, error) {
 		[]byte("log_pattern"),
 	}, nil), nil
 }
+
+// EncodeErrorCodeKey returns a canonical encoding key of error code
+func EncodeErrorCodeKey(code int32) ([]byte, error) {
+	return bytes.Join([][]byte{
+		errorCodeKeyPrefixBytes,
+		encodeErrorCode(code),
+	}, nil), nil
+}
+
+// EncodeErrorCodeCoverageKey returns a canonical encoding key of error code coverage data
+func EncodeErrorCodeCoverageKey(code int32) ([]byte, error) {
+	return bytes.Join([][]byte{
+		errorCodeCoverageKeyPrefixBytes,
+		encodeErrorCode(code),
+	}, nil), nil
+}
+
+// EncodeScanCheckpointKey returns a canonical encoding key of scan checkpoint of log
+func EncodeScanCheckpointKey(logPath string) ([]byte, error) {
+	return bytes.Join([][]byte{
+		scanCheckpointKeyPrefixBytes,
+		[]byte(logPath),
+	}, nil), nil
+}
+
+// encodeErrorCode encodes error code in big endian to keep the keys sorted by code
+func encodeErrorCode(code int32) []byte {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, uint32(code))
+	return buf
+}
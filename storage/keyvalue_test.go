@@ -82,7 +82,7 @@ func (t *testKeyValueSuite) TestRepoForPackage(c *C) {
 			ColumnOffset: 7274,
 		},
 		CovCount: 32,
-		CovCountByLog: map[string]int32{
+		CovCountByLog: map[string]int64{
 			"xxx": 32,
 		},
 	}
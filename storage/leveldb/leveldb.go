@@ -8,6 +8,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/IANTHEREAL/logutil/storage/keyvalue"
 	"github.com/jmhodges/levigo"
@@ -67,8 +70,16 @@ type Options struct {
 	// MustExist ensures that the given database exists before opening it.  If
 	// false and the database does not exist, it will be created.
 	MustExist bool
+
+	// LockTimeout is how long Open retries if the database is locked by another
+	// process, e.g. logcov scan --follow is flushing the coverage. Open doesn't
+	// retry if it's zero.
+	LockTimeout time.Duration
 }
 
+// lockRetryInterval is the interval of retrying to open the locked database
+const lockRetryInterval = 100 * time.Millisecond
+
 // ValidDB determines if the given path could be a LevelDB database.
 func ValidDB(path string) bool {
 	stat, err := os.Stat(path)
@@ -91,7 +102,12 @@ func Open(path string, opts *Options) (keyvalue.DB, error) {
 		options.SetWriteBufferSize(opts.WriteBufferSize)
 	}
 	db, err := levigo.Open(path, options)
+	for deadline := time.Now().Add(opts.LockTimeout); err != nil && strings.Contains(err.Error(), "lock") && time.Now().Before(deadline); {
+		time.Sleep(lockRetryInterval)
+		db, err = levigo.Open(path, options)
+	}
 	if err != nil {
+		cache.Close()
 		return nil, fmt.Errorf("could not open LevelDB at %q: %v", path, err)
 	}
 	largeReadOpts := levigo.NewReadOptions()
@@ -105,6 +121,93 @@ func Open(path string, opts *Options) (keyvalue.DB, error) {
 	}, nil
 }
 
+// releasableDB opens the LevelDB database on use and closes it on Release,
+// one LevelDB database can only be opened by one process, so other processes
+// can open the database after it's released
+type releasableDB struct {
+	sync.Mutex
+	path string
+	opts *Options
+	db   keyvalue.DB
+}
+
+// OpenReleasable returns a keyvalue DB backed by a LevelDB database at the given
+// filepath, the database is opened on use and closed by Release. Release must not
+// be called while any Iterator or Writer is in use.
+func OpenReleasable(path string, opts *Options) (keyvalue.DB, error) {
+	r := &releasableDB{path: path, opts: opts}
+	if _, err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *releasableDB) open() (keyvalue.DB, error) {
+	r.Lock()
+	defer r.Unlock()
+	if r.db == nil {
+		db, err := Open(r.path, r.opts)
+		if err != nil {
+			return nil, err
+		}
+		r.db = db
+	}
+	return r.db, nil
+}
+
+// Release implements the keyvalue.Releaser interface.
+func (r *releasableDB) Release() error {
+	r.Lock()
+	defer r.Unlock()
+	if r.db == nil {
+		return nil
+	}
+	err := r.db.Close(context.Background())
+	r.db = nil
+	return err
+}
+
+// Get implements part of the keyvalue.DB interface.
+func (r *releasableDB) Get(ctx context.Context, key []byte, opts *keyvalue.Options) ([]byte, error) {
+	db, err := r.open()
+	if err != nil {
+		return nil, err
+	}
+	return db.Get(ctx, key, opts)
+}
+
+// ScanPrefix implements part of the keyvalue.DB interface.
+func (r *releasableDB) ScanPrefix(ctx context.Context, prefix []byte, opts *keyvalue.Options) (keyvalue.Iterator, error) {
+	db, err := r.open()
+	if err != nil {
+		return nil, err
+	}
+	return db.ScanPrefix(ctx, prefix, opts)
+}
+
+// ScanRange implements part of the keyvalue.DB interface.
+func (r *releasableDB) ScanRange(ctx context.Context, rng *keyvalue.Range, opts *keyvalue.Options) (keyvalue.Iterator, error) {
+	db, err := r.open()
+	if err != nil {
+		return nil, err
+	}
+	return db.ScanRange(ctx, rng, opts)
+}
+
+// Writer implements part of the keyvalue.DB interface.
+func (r *releasableDB) Writer(ctx context.Context) (keyvalue.Writer, error) {
+	db, err := r.open()
+	if err != nil {
+		return nil, err
+	}
+	return db.Writer(ctx)
+}
+
+// Close implements part of the keyvalue.DB interface.
+func (r *releasableDB) Close(_ context.Context) error {
+	return r.Release()
+}
+
 // Close will close the underlying LevelDB database.
 func (s *levelDB) Close(_ context.Context) error {
 	s.db.Close()
@@ -179,6 +282,12 @@ func (w *writer) Write(key, val []byte) error {
 	return nil
 }
 
+// Delete implements part of the keyvalue.Writer interface.
+func (w *writer) Delete(key []byte) error {
+	w.WriteBatch.Delete(key)
+	return nil
+}
+
 // Close implements part of the keyvalue.Writer interface.
 func (w *writer) Close() error {
 	if err := w.s.db.Write(w.s.writeOpts, w.WriteBatch); err != nil {
@@ -0,0 +1,85 @@
+package leveldb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IANTHEREAL/logutil/storage/keyvalue"
+	. "github.com/pingcap/check"
+)
+
+func TestClient(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testLevelDBSuite{})
+
+type testLevelDBSuite struct {
+}
+
+func testWrite(c *C, db keyvalue.DB, key, value string) {
+	w, err := db.Writer(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(w.Write([]byte(key), []byte(value)), IsNil)
+	c.Assert(w.Close(), IsNil)
+}
+
+func (t *testLevelDBSuite) TestLockTimeout(c *C) {
+	path := filepath.Join(c.MkDir(), "db")
+	db, err := Open(path, nil)
+	c.Assert(err, IsNil)
+
+	// the locked database can't be opened without retry
+	_, err = Open(path, nil)
+	c.Assert(err, ErrorMatches, ".*lock.*")
+	_, err = Open(path, &Options{LockTimeout: 3 * lockRetryInterval})
+	c.Assert(err, ErrorMatches, ".*lock.*")
+
+	// the database is opened after it's closed by the holder
+	go func() {
+		time.Sleep(2 * lockRetryInterval)
+		db.Close(context.Background())
+	}()
+	db, err = Open(path, &Options{LockTimeout: 10 * time.Second})
+	c.Assert(err, IsNil)
+	c.Assert(db.Close(context.Background()), IsNil)
+}
+
+func (t *testLevelDBSuite) TestOpenReleasable(c *C) {
+	path := filepath.Join(c.MkDir(), "db")
+	db, err := OpenReleasable(path, &Options{LockTimeout: time.Second})
+	c.Assert(err, IsNil)
+	testWrite(c, db, "a", "1")
+
+	// the database is held until it's released
+	_, err = Open(path, nil)
+	c.Assert(err, ErrorMatches, ".*lock.*")
+	c.Assert(db.(keyvalue.Releaser).Release(), IsNil)
+	c.Assert(db.(keyvalue.Releaser).Release(), IsNil)
+
+	other, err := Open(path, nil)
+	c.Assert(err, IsNil)
+	value, err := other.Get(context.Background(), []byte("a"), nil)
+	c.Assert(err, IsNil)
+	c.Assert(string(value), Equals, "1")
+	testWrite(c, other, "b", "2")
+
+	// the released database is reopened on use after the other holder closes it
+	go func() {
+		time.Sleep(2 * lockRetryInterval)
+		other.Close(context.Background())
+	}()
+	value, err = db.Get(context.Background(), []byte("b"), nil)
+	c.Assert(err, IsNil)
+	c.Assert(string(value), Equals, "2")
+	c.Assert(db.Close(context.Background()), IsNil)
+
+	// it fails to open if the database is held longer than the lock timeout
+	other, err = Open(path, nil)
+	c.Assert(err, IsNil)
+	defer other.Close(context.Background())
+	_, err = OpenReleasable(path, &Options{LockTimeout: 2 * lockRetryInterval})
+	c.Assert(err, ErrorMatches, ".*lock.*")
+}